@@ -15,7 +15,10 @@
 package main
 
 import (
-	"time"
+	"context"
+	"errors"
+	"fmt"
+	"os"
 
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/cli"
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
@@ -26,32 +29,77 @@ import (
 )
 
 func main() {
+	os.Exit(run())
+}
+
+// run runs the command and returns the exit code of the process, 1 when the
+// command failed or panicked. Exiting is left to main so deferred flushes run.
+func run() (code int) {
 	// Collecting config from env or file or flag
 	cfg := config.GetConfig()
 
-	logger, err := logger.NewRootLogger(cfg.Debug, cfg.IsDevelopment)
+	logger, err := logger.NewRootLogger(cfg.Debug, cfg.IsDevelopment, cfg.Logging)
 	if err != nil {
-		panic(err)
+		fmt.Fprintln(os.Stderr, err)
+		return 1
 	}
+	defer logger.Sync() //nolint:errcheck
 	zap.ReplaceGlobals(logger)
 
+	// An empty DSN leaves sentry installed but without sending anything
+	err = sentry.Init(sentry.ClientOptions{
+		Dsn:              cfg.Sentry.DSN,
+		Environment:      cfg.Sentry.Environment,
+		Release:          cfg.Sentry.Release,
+		SampleRate:       cfg.Sentry.SampleRate,
+		SendDefaultPII:   cfg.Sentry.SendDefaultPII,
+		AttachStacktrace: true,
+	})
+	if err != nil {
+		logger.Fatal("error while initializing sentry", zap.Error(err))
+	}
+	defer sentry.Flush(cfg.Sentry.FlushTimeout)
+
 	// this function will logged error log in sentry
 	sentryLoggedFunc := func() {
 		err := recover()
 
 		if err != nil {
 			sentry.CurrentHub().Recover(err)
-			sentry.Flush(time.Second * 2)
+			sentry.Flush(cfg.Sentry.FlushTimeout)
 		}
 	}
 
+	// this function will log errors and panics of background routines and report them to sentry
+	routineErrorFunc := func(ctx context.Context, name string, err error) {
+		hub := sentry.CurrentHub().Clone()
+		hub.Scope().SetTag("routine", name)
+
+		var panicErr *routinewrapper.PanicError
+		if errors.As(err, &panicErr) {
+			logger.Error("recovered from panic in routine", zap.String("routine", name), zap.Any("panic", panicErr.Value), zap.ByteString("stack", panicErr.Stack))
+			hub.RecoverWithContext(ctx, panicErr.Value)
+			return
+		}
+		logger.Error("routine failed", zap.String("routine", name), zap.Error(err))
+		hub.CaptureException(err)
+	}
+
 	// routine wrapper will handle go routine error also an log into sentry
-	routinewrapper.Init(sentryLoggedFunc)
-	defer sentryLoggedFunc()
+	routinewrapper.Init(sentryLoggedFunc, routineErrorFunc)
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			logger.Error("recovered from panic", zap.Any("panic", recovered))
+			sentry.CurrentHub().Recover(recovered)
+			sentry.Flush(cfg.Sentry.FlushTimeout)
+			code = 1
+		}
+	}()
 
 	err = cli.Init(cfg, logger)
 	if err != nil {
-		panic(err)
+		logger.Error("command failed", zap.Error(err))
+		return 1
 	}
-
+	return 0
 }
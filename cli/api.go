@@ -1,22 +1,34 @@
 package cli
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"go.uber.org/zap"
 
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/database"
+	appLogger "git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/logger"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/middlewares"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/models"
 
 	_ "git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/docs"
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/routes"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/routinewrapper"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/swagger"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/health"
 	pMetrics "git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/prometheus"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/tracing"
 )
 
 // GetAPICommandDef runs app
@@ -27,43 +39,179 @@ func GetAPICommandDef(cfg config.AppConfig, logger *zap.Logger) cobra.Command {
 		Long:  `To start api`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
+			shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := shutdownTracing(context.Background()); err != nil {
+					logger.Error("error while flushing traces", zap.Error(err))
+				}
+			}()
+
+			accessLog, err := appLogger.NewAccessLogger(cfg.Logging)
+			if err != nil {
+				return err
+			}
+			if accessLog != nil {
+				defer accessLog.Sync() //nolint:errcheck
+			}
+
 			// Create fiber app
-			app := fiber.New(fiber.Config{})
-			app.Get("/swagger/*", swagger.HandlerDefault) // Serve Swagger UI
-			promMetrics := pMetrics.InitPrometheusMetrics()
+			serverConfig := fiber.Config{
+				BodyLimit:    cfg.HTTP.BodyLimit,
+				ReadTimeout:  cfg.HTTP.ReadTimeout,
+				WriteTimeout: cfg.HTTP.WriteTimeout,
+				IdleTimeout:  cfg.HTTP.IdleTimeout,
+			}
+			app := fiber.New(serverConfig)
+			promMetrics := pMetrics.InitPrometheusMetrics(cfg.Metrics)
+
+			// Middlewares must be registered before the routes they wrap
+			app.Use(middlewares.RequestIDHandler(logger))
+			app.Use(middlewares.TraceHandler())
+			app.Use(middlewares.LogHandler(logger, accessLog, promMetrics, cfg.Logging))
+			app.Use(middlewares.RecoverHandler(logger))
+			app.Use(middlewares.SentryHandler())
+			app.Use(middlewares.SecurityHeaders(cfg.HTTP))
+			corsHandler, err := middlewares.CORSHandler(cfg.CORS)
+			if err != nil {
+				return err
+			}
+			if corsHandler != nil {
+				app.Use(corsHandler)
+			}
+
+			app.Get(middlewares.SwaggerPath+"/*", swagger.HandlerDefault) // Serve Swagger UI
+
+			// Serve metrics on the api port unless a separate port is configured
+			metricsApp := app
+			if cfg.Metrics.Port != "" && cfg.Metrics.Port != cfg.Port {
+				metricsConfig := serverConfig
+				metricsConfig.DisableStartupMessage = true
+				metricsApp = fiber.New(metricsConfig)
+			}
+			metricsApp.Get(cfg.Metrics.Path, adaptor.HTTPHandler(promhttp.Handler()))
 
 			// Database connection
 			db, err := database.Connect(cfg.DB)
 			if err != nil {
 				return err
 			}
+			if sqlDB, ok := database.SQLDB(db); ok {
+				if err := pMetrics.RegisterDBStats(sqlDB, cfg.DB.Db); err != nil {
+					return err
+				}
+			}
+			if err := database.Instrument(db, promMetrics.ObserveQuery); err != nil {
+				return err
+			}
+
+			// Subsystems register their health checks while being set up
+			checks := health.NewRegistry(cfg.Health)
+			if err := database.RegisterHealthChecks(checks, db, cfg.DB); err != nil {
+				return err
+			}
 
 			// Setup routes
-			err = routes.Setup(app, db, logger, promMetrics)
+			state := &health.State{}
+			err = routes.Setup(app, cfg, db, logger, promMetrics, checks, state)
 			if err != nil {
 				return err
 			}
 
+			// Refresh catalog gauges in the background instead of on every scrape
+			catalogModel, err := models.InitCatalogModel(db)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			routinewrapper.Go(ctx, "catalog metrics refresher", func(ctx context.Context) error {
+				promMetrics.RunCatalogRefresher(ctx, &catalogModel, cfg.Metrics.CatalogRefreshInterval, logger)
+				return nil
+			})
+
+			if levels := appLogger.RuntimeLevels(); levels != nil {
+				routinewrapper.Go(ctx, "log level signals", func(ctx context.Context) error {
+					levels.HandleSignals(ctx, cfg.Logging.LevelRevertAfter, logger)
+					return nil
+				})
+			}
+
 			interrupt := make(chan os.Signal, 1)
 			signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
 
-			// Start server in a goroutine
-			go func() {
+			// Start servers in background routines; a server that cannot
+			// listen stops the command instead of panicking
+			listenErr := make(chan error, 2)
+			routinewrapper.Go(ctx, "api server", func(ctx context.Context) error {
 				if err := app.Listen(cfg.Host + ":" + cfg.Port); err != nil {
-					logger.Panic(err.Error())
+					listenErr <- err
+					return err
 				}
-			}()
+				return nil
+			})
 
-			<-interrupt
-			logger.Info("gracefully shutting down...")
-			if err := app.Shutdown(); err != nil {
-				logger.Panic("error while shutting down server", zap.Error(err))
+			if metricsApp != app {
+				routinewrapper.Go(ctx, "metrics server", func(ctx context.Context) error {
+					if err := metricsApp.Listen(cfg.Host + ":" + cfg.Metrics.Port); err != nil {
+						listenErr <- err
+						return err
+					}
+					return nil
+				})
 			}
 
-			logger.Info("server stopped receiving new requests.")
-			return nil
+			var runErr error
+			select {
+			case <-interrupt:
+				logger.Info("gracefully shutting down...")
+				// Fail readiness first, so no new traffic is routed here while draining.
+				// A second signal skips the wait.
+				state.Drain()
+				select {
+				case <-time.After(cfg.Shutdown.DrainDelay):
+				case <-interrupt:
+				}
+			case runErr = <-listenErr:
+				logger.Error("server stopped listening, shutting down", zap.Error(runErr))
+				state.Drain()
+			}
+			servers := []*fiber.App{app}
+			if metricsApp != app {
+				servers = append(servers, metricsApp)
+			}
+			return errors.Join(runErr, shutdown(cfg, logger, servers, cancel))
 		},
 	}
 
 	return apiCommand
 }
+
+// shutdown stops servers from accepting connections and waits for in-flight
+// requests, then stops background routines through cancel and waits for them,
+// and closes the database. All of it has to finish within the grace period.
+func shutdown(cfg config.AppConfig, logger *zap.Logger, servers []*fiber.App, cancel context.CancelFunc) error {
+	deadline := time.Now().Add(cfg.Shutdown.GracePeriod)
+	ctx, cancelGrace := context.WithDeadline(context.Background(), deadline)
+	defer cancelGrace()
+
+	var errs []error
+	for _, server := range servers {
+		if err := server.ShutdownWithTimeout(time.Until(deadline)); err != nil {
+			errs = append(errs, fmt.Errorf("shutting down server: %w", err))
+		}
+	}
+	logger.Info("server stopped receiving new requests.")
+
+	cancel()
+	if err := routinewrapper.Wait(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("waiting for background routines: %w", err))
+	}
+
+	if err := database.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("closing database: %w", err))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/database"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/models"
+	"github.com/spf13/cobra"
+)
+
+// GetAPIKeyCommandDef initializes the api-key command
+func GetAPIKeyCommandDef(cfg config.AppConfig) cobra.Command {
+	apiKeyCmd := cobra.Command{
+		Use:   "api-key [sub command]",
+		Short: "To manage api keys",
+		Long: `This command is used to manage the keys authenticating api requests.
+	It has create, list and revoke sub commands`,
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	var name string
+	var scopes []string
+	createCmd := cobra.Command{
+		Use:   "create",
+		Short: "It will create an api key",
+		Long: `It will create an api key with the given scopes and print it.
+	The key is only stored hashed, so it cannot be shown again.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if err := models.ValidateScopes(scopes); err != nil {
+				return err
+			}
+			model, err := apiKeyModel(cfg)
+			if err != nil {
+				return err
+			}
+			defer closeDatabase(&err)
+
+			apiKey, key, err := model.CreateAPIKey(cmd.Context(), name, scopes)
+			if err != nil {
+				return fmt.Errorf("failed to create api key: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Created api key %d (%s) with scopes %s\n", apiKey.ID, apiKey.Name, strings.Join(apiKey.Scopes, ","))
+			fmt.Fprintf(cmd.OutOrStdout(), "%s\n", key)
+			return nil
+		},
+	}
+	createCmd.Flags().StringVarP(&name, "name", "n", "", "name describing who uses the key")
+	createCmd.Flags().StringSliceVarP(&scopes, "scope", "s", nil, fmt.Sprintf("scopes granted to the key, any of %s", strings.Join(models.Scopes, ", ")))
+	_ = createCmd.MarkFlagRequired("name")
+	_ = createCmd.MarkFlagRequired("scope")
+
+	listCmd := cobra.Command{
+		Use:   "list",
+		Short: "It will list api keys",
+		Long:  `It will list all api keys, including revoked ones`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			model, err := apiKeyModel(cfg)
+			if err != nil {
+				return err
+			}
+			defer closeDatabase(&err)
+
+			apiKeys, err := model.GetAPIKeys(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to list api keys: %w", err)
+			}
+
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "ID\tNAME\tPREFIX\tSCOPES\tCREATED\tREVOKED")
+			for _, apiKey := range apiKeys {
+				revoked := "-"
+				if apiKey.Revoked() {
+					revoked = apiKey.RevokedAt.Time.Format("2006-01-02 15:04")
+				}
+				fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n", apiKey.ID, apiKey.Name, apiKey.Prefix, strings.Join(apiKey.Scopes, ","), apiKey.CreatedAt.Format("2006-01-02 15:04"), revoked)
+			}
+			return tw.Flush()
+		},
+	}
+
+	revokeCmd := cobra.Command{
+		Use:   "revoke <id>",
+		Short: "It will revoke an api key",
+		Long:  `It will revoke the api key with the given id, requests using it are rejected from then on`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid api key id %q", args[0])
+			}
+			model, err := apiKeyModel(cfg)
+			if err != nil {
+				return err
+			}
+			defer closeDatabase(&err)
+
+			err = model.RevokeAPIKey(cmd.Context(), id)
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("api key %d not found", id)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to revoke api key: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Revoked api key %d\n", id)
+			return nil
+		},
+	}
+
+	apiKeyCmd.AddCommand(&createCmd, &listCmd, &revokeCmd)
+	return apiKeyCmd
+}
+
+// apiKeyModel connects to the database, closeDatabase closes the connection
+func apiKeyModel(cfg config.AppConfig) (models.APIKeyModel, error) {
+	dbConnGoqu, err := database.Connect(cfg.DB)
+	if err != nil {
+		return models.APIKeyModel{}, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	model, err := models.InitAPIKeyModel(dbConnGoqu)
+	if err != nil {
+		closeDatabase(&err)
+		return models.APIKeyModel{}, err
+	}
+	return model, nil
+}
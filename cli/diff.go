@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/database"
+	"github.com/spf13/cobra"
+)
+
+// Output formats supported by the diff command
+const (
+	diffFormatTable = "table"
+	diffFormatJSON  = "json"
+	diffFormatCSV   = "csv"
+)
+
+// GetDiffCommandDef initializes the diff command
+func GetDiffCommandDef(cfg config.AppConfig) cobra.Command {
+	var csvPath, format string
+
+	diffCmd := cobra.Command{
+		Use:   "diff",
+		Short: "Compare an app data CSV against the database",
+		Long: `This command compares an app data CSV with the apps table by app name and category.
+	It reports added, removed and changed apps with rating, installs and version differences.`,
+		Args: cobra.MinimumNArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if format != diffFormatTable && format != diffFormatJSON && format != diffFormatCSV {
+				return fmt.Errorf("unsupported output format %q, expected one of table, json, csv", format)
+			}
+
+			dbConnGoqu, err := database.Connect(cfg.DB)
+			if err != nil {
+				return fmt.Errorf("failed to connect to database for diff: %w", err)
+			}
+			defer closeDatabase(&err)
+
+			diff, err := database.DiffAppData(csvPath, dbConnGoqu, cmd.ErrOrStderr())
+			if err != nil {
+				return fmt.Errorf("failed to diff app data: %w", err)
+			}
+
+			switch format {
+			case diffFormatJSON:
+				return writeDiffJSON(cmd.OutOrStdout(), diff)
+			case diffFormatCSV:
+				return writeDiffCSV(cmd.OutOrStdout(), diff)
+			default:
+				return writeDiffTable(cmd.OutOrStdout(), diff)
+			}
+		},
+	}
+
+	diffCmd.Flags().StringVarP(&csvPath, "file", "f", cfg.AppDataCSVPath, "path of the app data CSV to compare")
+	diffCmd.Flags().StringVarP(&format, "output", "o", diffFormatTable, "output format: table, json or csv")
+	return diffCmd
+}
+
+// diffRows flattens a diff into status, app, category, field, old, new records.
+func diffRows(diff database.AppDiff) [][]string {
+	var rows [][]string
+	for _, entry := range diff.Added {
+		rows = append(rows, []string{"added", entry.App, entry.Category, "", "", ""})
+	}
+	for _, entry := range diff.Removed {
+		rows = append(rows, []string{"removed", entry.App, entry.Category, "", "", ""})
+	}
+	for _, entry := range diff.Changed {
+		for _, change := range entry.Changes {
+			rows = append(rows, []string{"changed", entry.App, entry.Category, change.Field, change.Old, change.New})
+		}
+	}
+	return rows
+}
+
+var diffHeader = []string{"status", "app", "category", "field", "old", "new"}
+
+func writeDiffTable(w io.Writer, diff database.AppDiff) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tAPP\tCATEGORY\tFIELD\tOLD\tNEW")
+	for _, row := range diffRows(diff) {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", row[0], row[1], row[2], row[3], row[4], row[5])
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d added, %d removed, %d changed\n", len(diff.Added), len(diff.Removed), len(diff.Changed))
+	return err
+}
+
+func writeDiffJSON(w io.Writer, diff database.AppDiff) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(diff)
+}
+
+func writeDiffCSV(w io.Writer, diff database.AppDiff) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(diffHeader); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(diffRows(diff)); err != nil {
+		return err
+	}
+	return writer.Error()
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWriteDiff tests the output formats of the diff command
+func TestWriteDiff(t *testing.T) {
+	diff := database.AppDiff{
+		Added:   []database.AppDiffEntry{{App: "Chat", Category: "SOCIAL"}},
+		Removed: []database.AppDiffEntry{{App: "Notes", Category: "PRODUCTIVITY"}},
+		Changed: []database.AppDiffEntry{{App: "Maps, Pro", Category: "TRAVEL", Changes: []database.AppFieldChange{
+			{Field: "rating", Old: "4.1", New: "4.5"},
+			{Field: "installs", Old: "1,000+", New: "5,000+"},
+		}}},
+	}
+	empty := database.AppDiff{Added: []database.AppDiffEntry{}, Removed: []database.AppDiffEntry{}, Changed: []database.AppDiffEntry{}}
+
+	tests := []struct {
+		name  string
+		write func(io.Writer, database.AppDiff) error
+		diff  database.AppDiff
+		want  string
+	}{
+		{
+			// Test case 1: Table lists a row per added, removed and changed field
+			name:  "table",
+			write: writeDiffTable,
+			diff:  diff,
+			want: "STATUS   APP        CATEGORY      FIELD     OLD     NEW\n" +
+				"added    Chat       SOCIAL                          \n" +
+				"removed  Notes      PRODUCTIVITY                    \n" +
+				"changed  Maps, Pro  TRAVEL        rating    4.1     4.5\n" +
+				"changed  Maps, Pro  TRAVEL        installs  1,000+  5,000+\n" +
+				"\n1 added, 1 removed, 1 changed\n",
+		},
+		{
+			// Test case 2: Table of an empty diff only has the header and summary
+			name:  "empty table",
+			write: writeDiffTable,
+			diff:  empty,
+			want:  "STATUS  APP  CATEGORY  FIELD  OLD  NEW\n\n0 added, 0 removed, 0 changed\n",
+		},
+		{
+			// Test case 3: JSON is the indented diff
+			name:  "json",
+			write: writeDiffJSON,
+			diff:  diff,
+			want: `{
+  "added": [
+    {
+      "app": "Chat",
+      "category": "SOCIAL"
+    }
+  ],
+  "removed": [
+    {
+      "app": "Notes",
+      "category": "PRODUCTIVITY"
+    }
+  ],
+  "changed": [
+    {
+      "app": "Maps, Pro",
+      "category": "TRAVEL",
+      "changes": [
+        {
+          "field": "rating",
+          "old": "4.1",
+          "new": "4.5"
+        },
+        {
+          "field": "installs",
+          "old": "1,000+",
+          "new": "5,000+"
+        }
+      ]
+    }
+  ]
+}
+`,
+		},
+		{
+			// Test case 4: JSON of an empty diff has empty lists, not nulls
+			name:  "empty json",
+			write: writeDiffJSON,
+			diff:  empty,
+			want:  "{\n  \"added\": [],\n  \"removed\": [],\n  \"changed\": []\n}\n",
+		},
+		{
+			// Test case 5: CSV quotes values containing commas
+			name:  "csv",
+			write: writeDiffCSV,
+			diff:  diff,
+			want: "status,app,category,field,old,new\n" +
+				"added,Chat,SOCIAL,,,\n" +
+				"removed,Notes,PRODUCTIVITY,,,\n" +
+				"changed,\"Maps, Pro\",TRAVEL,rating,4.1,4.5\n" +
+				"changed,\"Maps, Pro\",TRAVEL,installs,\"1,000+\",\"5,000+\"\n",
+		},
+		{
+			// Test case 6: CSV of an empty diff only has the header
+			name:  "empty csv",
+			write: writeDiffCSV,
+			diff:  empty,
+			want:  "status,app,category,field,old,new\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			assert.Nil(t, tt.write(&out, tt.diff))
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
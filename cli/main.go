@@ -1,7 +1,11 @@
 package cli
 
 import (
+	"errors"
+	"fmt"
+
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/database"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
@@ -11,8 +15,18 @@ func Init(cfg config.AppConfig, logger *zap.Logger) error {
 	migrationCmd := GetMigrationCommandDef(cfg)
 	apiCmd := GetAPICommandDef(cfg, logger)
 	seedCmd := GetSeedCommandDef(cfg) // Add the seed command
+	diffCmd := GetDiffCommandDef(cfg)
+	apiKeyCmd := GetAPIKeyCommandDef(cfg)
 
 	rootCmd := &cobra.Command{Use: "golang-api"}
-	rootCmd.AddCommand(&migrationCmd, &apiCmd, &seedCmd)
+	rootCmd.AddCommand(&migrationCmd, &apiCmd, &seedCmd, &diffCmd, &apiKeyCmd)
 	return rootCmd.Execute()
 }
+
+// closeDatabase closes the pool opened by database.Connect and joins a
+// failure to close it to *err, the error returned by the command
+func closeDatabase(err *error) {
+	if closeErr := database.Close(); closeErr != nil {
+		*err = errors.Join(*err, fmt.Errorf("closing database: %w", closeErr))
+	}
+}
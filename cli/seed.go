@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"database/sql"
 	"fmt"
 
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
@@ -25,7 +24,7 @@ func GetSeedCommandDef(cfg config.AppConfig) cobra.Command {
 			}
 
 			// Get the underlying *sql.DB for closing
-			if sqlDB, ok := dbConnGoqu.Db.(*sql.DB); ok {
+			if sqlDB, ok := database.SQLDB(dbConnGoqu); ok {
 				defer func() {
 					if err := sqlDB.Close(); err != nil {
 						fmt.Println("Error closing database connection:", err)
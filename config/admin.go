@@ -0,0 +1,7 @@
+package config
+
+// AdminConfig type of admin endpoints config object
+type AdminConfig struct {
+	// Token authenticates requests to /admin as "Authorization: Bearer <token>", admin endpoints are disabled when empty
+	Token string `envconfig:"ADMIN_TOKEN"`
+}
@@ -0,0 +1,11 @@
+package config
+
+import "time"
+
+// HealthConfig type of health probes config object
+type HealthConfig struct {
+	// CheckTimeout bounds each dependency check of the probes, unless the check sets its own
+	CheckTimeout time.Duration `envconfig:"HEALTH_CHECK_TIMEOUT" default:"2s"`
+	// CacheTTL is how long check results are reused, so frequent probes don't hammer dependencies
+	CacheTTL time.Duration `envconfig:"HEALTH_CACHE_TTL" default:"2s"`
+}
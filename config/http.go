@@ -0,0 +1,31 @@
+package config
+
+import "time"
+
+// HTTPConfig type of api server limits and security headers config object
+type HTTPConfig struct {
+	// BodyLimit is the largest request body accepted, in bytes
+	BodyLimit int `envconfig:"HTTP_BODY_LIMIT" default:"4194304"`
+	// ReadTimeout bounds reading a whole request, including the body
+	ReadTimeout time.Duration `envconfig:"HTTP_READ_TIMEOUT" default:"10s"`
+	// WriteTimeout bounds writing a response
+	WriteTimeout time.Duration `envconfig:"HTTP_WRITE_TIMEOUT" default:"30s"`
+	// IdleTimeout is how long keep-alive connections wait for the next request
+	IdleTimeout time.Duration `envconfig:"HTTP_IDLE_TIMEOUT" default:"120s"`
+	// HSTSMaxAge is the max-age of the Strict-Transport-Security header sent over HTTPS, 0 disables it
+	HSTSMaxAge time.Duration `envconfig:"HTTP_HSTS_MAX_AGE" default:"8760h"`
+}
+
+// CORSConfig type of cross-origin resource sharing config object
+type CORSConfig struct {
+	// AllowOrigins lists the origins browsers may call the api from, CORS is disabled when empty
+	AllowOrigins []string `envconfig:"CORS_ALLOW_ORIGINS"`
+	// AllowMethods lists the methods allowed in cross-origin requests
+	AllowMethods []string `envconfig:"CORS_ALLOW_METHODS" default:"GET,HEAD,POST,PUT,DELETE"`
+	// AllowHeaders lists the request headers allowed in cross-origin requests
+	AllowHeaders []string `envconfig:"CORS_ALLOW_HEADERS" default:"Authorization,Content-Type,Accept,X-API-Key,X-Session-Token,X-Request-ID"`
+	// AllowCredentials lets browsers send cookies, like the Kratos session cookie, it can't be combined with the * origin
+	AllowCredentials bool `envconfig:"CORS_ALLOW_CREDENTIALS"`
+	// MaxAge is how long browsers may cache preflight responses
+	MaxAge time.Duration `envconfig:"CORS_MAX_AGE" default:"10m"`
+}
@@ -0,0 +1,29 @@
+package config
+
+import "time"
+
+// JWTConfig type of JWT authentication config object
+type JWTConfig struct {
+	// JWKSFile is a local JWKS document, it takes precedence over JWKSURL
+	JWKSFile string `envconfig:"JWT_JWKS_FILE"`
+	// JWKSURL is fetched for the JWKS, JWT authentication is disabled when both are empty
+	JWKSURL string `envconfig:"JWT_JWKS_URL"`
+	// JWKSRefreshInterval is how long keys are cached before the JWKS is loaded again
+	JWKSRefreshInterval time.Duration `envconfig:"JWT_JWKS_REFRESH_INTERVAL" default:"15m"`
+	// Algorithms accepted in token headers
+	Algorithms []string `envconfig:"JWT_ALGORITHMS" default:"RS256,ES256,HS256"`
+	// Issuer and Audience are checked against the iss and aud claims when set
+	Issuer   string `envconfig:"JWT_ISSUER"`
+	Audience string `envconfig:"JWT_AUDIENCE"`
+	// Leeway tolerates clock skew when checking exp, nbf and iat
+	Leeway time.Duration `envconfig:"JWT_LEEWAY" default:"30s"`
+	// RolesClaim is the dotted path of the claim listing roles, e.g. realm_access.roles
+	RolesClaim string `envconfig:"JWT_ROLES_CLAIM" default:"roles"`
+	// RoleMapping renames claim values to roles as claim:role pairs, unmapped values are kept as is
+	RoleMapping map[string]string `envconfig:"JWT_ROLE_MAPPING"`
+}
+
+// Enabled reports whether a JWKS is configured
+func (cfg JWTConfig) Enabled() bool {
+	return cfg.JWKSFile != "" || cfg.JWKSURL != ""
+}
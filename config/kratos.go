@@ -0,0 +1,15 @@
+package config
+
+import "time"
+
+// KratosConfig type of Ory Kratos session authentication config object
+type KratosConfig struct {
+	// WhoamiURL is the /sessions/whoami endpoint of the Kratos public API, session authentication is disabled when empty
+	WhoamiURL string `envconfig:"KRATOS_WHOAMI_URL"`
+	// SessionCookie is the name of the browser session cookie forwarded to Kratos
+	SessionCookie string `envconfig:"KRATOS_SESSION_COOKIE" default:"ory_kratos_session"`
+	// CacheTTL is how long a resolved session is reused before asking Kratos again
+	CacheTTL time.Duration `envconfig:"KRATOS_CACHE_TTL" default:"30s"`
+	// Timeout bounds each whoami request
+	Timeout time.Duration `envconfig:"KRATOS_TIMEOUT" default:"5s"`
+}
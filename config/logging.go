@@ -0,0 +1,45 @@
+package config
+
+import "time"
+
+// Access log formats
+const (
+	AccessLogFormatCommon   = "common"
+	AccessLogFormatCombined = "combined"
+	AccessLogFormatJSON     = "json"
+)
+
+// LoggingConfig type of logging config object
+type LoggingConfig struct {
+	// RedactHeaders are request and response headers whose values are never logged, case insensitive
+	RedactHeaders []string `envconfig:"LOG_REDACT_HEADERS" default:"Authorization,Cookie,Set-Cookie,X-Api-Key,X-Session-Token"`
+	// RedactFields are JSON body fields whose values are never logged, case insensitive, at any depth
+	RedactFields []string `envconfig:"LOG_REDACT_FIELDS" default:"password,token,secret,api_key"`
+	// MaxBodySize is the number of request and response body bytes logged, 0
+	// disables body logging. JSON bodies over it are omitted when fields are redacted.
+	MaxBodySize int `envconfig:"LOG_MAX_BODY_SIZE" default:"2048"`
+	// SuccessSampleRate is the fraction of successful requests that are logged; failures are always logged
+	SuccessSampleRate float64 `envconfig:"LOG_SUCCESS_SAMPLE_RATE" default:"1"`
+	// IgnorePaths are request paths that are never logged, * and ? globs match within a path segment
+	IgnorePaths []string `envconfig:"LOG_IGNORE_PATHS" default:"/docs,/assets/*,/favicon.ico"`
+	// LevelRevertAfter is how long a log level changed at runtime lasts unless a duration is given
+	LevelRevertAfter time.Duration `envconfig:"LOG_LEVEL_REVERT_AFTER" default:"15m"`
+
+	// Stdout writes logs to stdout, it is always on while FilePath is empty
+	Stdout bool `envconfig:"LOG_STDOUT" default:"true"`
+	// FilePath is a log file written as JSON and rotated by size and age
+	FilePath string `envconfig:"LOG_FILE_PATH"`
+	// FileMaxSize is the size in megabytes at which log files are rotated
+	FileMaxSize int `envconfig:"LOG_FILE_MAX_SIZE" default:"100"`
+	// FileMaxAge is the number of days rotated log files are kept, 0 keeps them regardless of age
+	FileMaxAge int `envconfig:"LOG_FILE_MAX_AGE" default:"28"`
+	// FileMaxBackups is the number of rotated log files kept, 0 keeps them all
+	FileMaxBackups int `envconfig:"LOG_FILE_MAX_BACKUPS" default:"10"`
+	// FileCompress gzips rotated log files
+	FileCompress bool `envconfig:"LOG_FILE_COMPRESS" default:"true"`
+
+	// AccessLogFormat is one of common, combined or json, the access log is disabled when empty
+	AccessLogFormat string `envconfig:"LOG_ACCESS_FORMAT"`
+	// AccessLogPath is a rotated access log file, stdout when empty
+	AccessLogPath string `envconfig:"LOG_ACCESS_FILE_PATH"`
+}
@@ -19,6 +19,19 @@ type AppConfig struct {
 	Host              string `envconfig:"HOST"`
 	Port              string `envconfig:"APP_PORT"`
 	DB                DBConfig
+	Metrics           MetricsConfig
+	Tracing           TracingConfig
+	Logging           LoggingConfig
+	Sentry            SentryConfig
+	Admin             AdminConfig
+	JWT               JWTConfig
+	Kratos            KratosConfig
+	RBAC              RBACConfig
+	RateLimit         RateLimitConfig
+	HTTP              HTTPConfig
+	CORS              CORSConfig
+	Health            HealthConfig
+	Shutdown          ShutdownConfig
 	AppDataCSVPath    string `envconfig:"APP_DATA_CSV_PATH"`
 	ReviewDataCSVPath string `envconfig:"REVIEW_DATA_CSV_PATH"`
 }
@@ -0,0 +1,16 @@
+package config
+
+import "time"
+
+// MetricsConfig type of prometheus metrics config object
+type MetricsConfig struct {
+	Path string `envconfig:"METRICS_PATH" default:"/metrics"`
+	// Port serves metrics on a separate listener when set to a port other than APP_PORT
+	Port string `envconfig:"METRICS_PORT"`
+	// DurationBuckets are the request latency histogram buckets in seconds, comma separated
+	DurationBuckets []float64 `envconfig:"METRICS_DURATION_BUCKETS"`
+	// QueryDurationBuckets are the database query latency histogram buckets in seconds, comma separated
+	QueryDurationBuckets []float64 `envconfig:"METRICS_QUERY_DURATION_BUCKETS"`
+	// CatalogRefreshInterval is how often catalog gauges are recomputed from the database
+	CatalogRefreshInterval time.Duration `envconfig:"METRICS_CATALOG_REFRESH_INTERVAL" default:"1m"`
+}
@@ -0,0 +1,13 @@
+package config
+
+import "time"
+
+// RateLimitConfig type of per-client rate limiting config object
+type RateLimitConfig struct {
+	// Read is how many GET, HEAD and OPTIONS requests a client may send per Period, 0 disables the limit
+	Read int `envconfig:"RATE_LIMIT_READ" default:"300"`
+	// Write is how many other requests a client may send per Period, 0 disables the limit
+	Write int `envconfig:"RATE_LIMIT_WRITE" default:"60"`
+	// Period is how long an empty bucket takes to refill, clients may burst up to the whole limit
+	Period time.Duration `envconfig:"RATE_LIMIT_PERIOD" default:"1m"`
+}
@@ -0,0 +1,9 @@
+package config
+
+// RBACConfig type of role-based access control config object
+type RBACConfig struct {
+	// PolicyFile lists the roles allowed per route and method, it is reloaded through POST /admin/rbac/reload
+	PolicyFile string `envconfig:"RBAC_POLICY_FILE" default:"rbac_policy.yaml"`
+	// Tokens maps static bearer tokens to roles as token:role pairs
+	Tokens map[string]string `envconfig:"RBAC_TOKENS"`
+}
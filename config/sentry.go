@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+// SentryConfig type of sentry error reporting config object
+type SentryConfig struct {
+	// DSN of the Sentry project, reporting is disabled when empty
+	DSN         string `envconfig:"SENTRY_DSN"`
+	Environment string `envconfig:"SENTRY_ENVIRONMENT" default:"development"`
+	Release     string `envconfig:"SENTRY_RELEASE"`
+	// SampleRate is the fraction of error events sent, between 0 and 1
+	SampleRate float64 `envconfig:"SENTRY_SAMPLE_RATE" default:"1"`
+	// SendDefaultPII attaches client IP addresses and unfiltered headers to events
+	SendDefaultPII bool `envconfig:"SENTRY_SEND_DEFAULT_PII"`
+	// FlushTimeout bounds how long buffered events are sent for before exiting
+	FlushTimeout time.Duration `envconfig:"SENTRY_FLUSH_TIMEOUT" default:"2s"`
+}
@@ -0,0 +1,11 @@
+package config
+
+import "time"
+
+// ShutdownConfig type of graceful shutdown config object
+type ShutdownConfig struct {
+	// DrainDelay is how long readiness fails before the server stops accepting connections, so load balancers stop routing here first
+	DrainDelay time.Duration `envconfig:"SHUTDOWN_DRAIN_DELAY" default:"5s"`
+	// GracePeriod bounds finishing in-flight requests and background jobs once draining started
+	GracePeriod time.Duration `envconfig:"SHUTDOWN_GRACE_PERIOD" default:"30s"`
+}
@@ -0,0 +1,24 @@
+package config
+
+// Tracing exporters
+const (
+	TracingExporterNone     = "none"
+	TracingExporterStdout   = "stdout"
+	TracingExporterOTLPFile = "otlp-file"
+	TracingExporterOTLP     = "otlp"
+)
+
+// TracingConfig type of opentelemetry tracing config object
+type TracingConfig struct {
+	// Exporter is one of none, stdout, otlp-file or otlp
+	Exporter    string `envconfig:"TRACING_EXPORTER" default:"none"`
+	ServiceName string `envconfig:"TRACING_SERVICE_NAME" default:"fiber-csv-app"`
+	// FilePath receives one OTLP JSON export request per line for the otlp-file exporter
+	FilePath string `envconfig:"TRACING_FILE_PATH" default:"traces.jsonl"`
+	// Endpoint is the host:port of the collector's OTLP/HTTP receiver for the otlp exporter
+	Endpoint string `envconfig:"TRACING_OTLP_ENDPOINT" default:"localhost:4318"`
+	// Insecure sends spans to the collector over plain HTTP
+	Insecure bool `envconfig:"TRACING_OTLP_INSECURE" default:"true"`
+	// SampleRatio is the fraction of new traces recorded; incoming sampled parents are always followed
+	SampleRatio float64 `envconfig:"TRACING_SAMPLE_RATIO" default:"1"`
+}
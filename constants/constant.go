@@ -6,6 +6,9 @@ const (
 	Limit            = "limit"
 	Offset           = "page"
 	ParamFilterPrice = "price"
+	ParamMatch       = "match"
+	ParamLogger      = "logger"
+	ParamVerbose     = "verbose"
 )
 
 // Error Messages
@@ -44,6 +47,7 @@ const (
 )
 const (
 	ErrorInvalidRequestBody     = "Invalid request body"
+	ErrorValidationFailed       = "Failed to validate request body"
 	ErrorFiledToCreateApp       = "Failed to create app data: "
 	ErrorFiledToCreateReviewApp = "Failed to create review data: "
 	ErrorlimitAccess            = "Limit exceeded: max 500 apps per request allowed for this PC"
@@ -59,3 +63,81 @@ const (
 const (
 	ErrHealthCheckDb = "error while health checking of db"
 )
+const (
+	ErrorInvalidMatch         = "Invalid match value, expected exact or fuzzy"
+	FailedToGetDuplicates     = "Failed to get duplicate apps"
+	ErrorInvalidMerge         = "Canonical app cannot be listed as a duplicate"
+	ErrorDuplicateAppNotFound = "One or more duplicate apps not found"
+	ErrorFailedToMergeApps    = "Failed to merge duplicate apps"
+)
+const (
+	ErrorInternalServer = "Internal server error"
+)
+const (
+	ErrorUnauthorized       = "Missing or invalid credentials"
+	ErrorAdminDisabled      = "Admin endpoints are disabled"
+	ErrorInvalidRevertAfter = "Invalid revert_after duration"
+	ErrorInsufficientScope  = "Caller is missing a required scope"
+	ErrorRoleNotAllowed     = "Role is not allowed to perform this action"
+	ErrorReloadPolicy       = "Failed to reload rbac policy"
+	ErrorRateLimited        = "Rate limit exceeded, retry after the Retry-After delay"
+)
+
+// HeaderRequestID is the header carrying the request ID
+const HeaderRequestID = "X-Request-ID"
+
+// MaxRequestIDLength is the longest client supplied request ID that is accepted
+const MaxRequestIDLength = 128
+
+// Keys of values stored in fiber.Ctx locals
+const (
+	LocalRequestID = "requestID"
+	LocalLogger    = "logger"
+	// LocalUserID is set by authentication middlewares to the authenticated subject
+	LocalUserID = "userID"
+)
+
+// RFC 7807 problem types. Each error message constant maps to a stable type
+// URI so problem+json clients can branch on it instead of the message text.
+const (
+	ProblemTypeBase       = "/problems/"
+	ProblemTypeDefault    = "about:blank"
+	ProblemTypeValidation = ProblemTypeBase + "validation-failed"
+)
+
+// ProblemTypes maps error messages to their problem type URI
+var ProblemTypes = map[string]string{
+	ErrorInvalidLimit:           ProblemTypeBase + "invalid-limit",
+	ErrorInvalidOffset:          ProblemTypeBase + "invalid-offset",
+	ErrorInvalidAppID:           ProblemTypeBase + "invalid-app-id",
+	ErrorAppNotFound:            ProblemTypeBase + "app-not-found",
+	ErrorLoadingCache:           ProblemTypeBase + "cache-load-failed",
+	FailedToGetApp:              ProblemTypeBase + "get-app-failed",
+	ErrorFiledToUpdateApp:       ProblemTypeBase + "update-app-failed",
+	ErrorInvalidReviewID:        ProblemTypeBase + "invalid-review-id",
+	ErrorReviewNotFound:         ProblemTypeBase + "review-not-found",
+	FailedToGetReview:           ProblemTypeBase + "get-review-failed",
+	FailedToGetReviews:          ProblemTypeBase + "get-reviews-failed",
+	FailedToUpdateReviews:       ProblemTypeBase + "update-review-failed",
+	ErrorInvalidRequestBody:     ProblemTypeBase + "invalid-request-body",
+	ErrorValidationFailed:       ProblemTypeBase + "validation-error",
+	ErrorFiledToCreateApp:       ProblemTypeBase + "create-app-failed",
+	ErrorFiledToCreateReviewApp: ProblemTypeBase + "create-review-failed",
+	ErrorlimitAccess:            ProblemTypeBase + "limit-exceeded",
+	ErrorFaiedToDeleteReview:    ProblemTypeBase + "delete-review-failed",
+	ErrorFaiedToDeleteApp:       ProblemTypeBase + "delete-app-failed",
+	ErrHealthCheckDb:            ProblemTypeBase + "db-health-check-failed",
+	ErrorInvalidMatch:           ProblemTypeBase + "invalid-match",
+	FailedToGetDuplicates:       ProblemTypeBase + "get-duplicates-failed",
+	ErrorInvalidMerge:           ProblemTypeBase + "invalid-merge",
+	ErrorDuplicateAppNotFound:   ProblemTypeBase + "duplicate-app-not-found",
+	ErrorFailedToMergeApps:      ProblemTypeBase + "merge-apps-failed",
+	ErrorInternalServer:         ProblemTypeBase + "internal-error",
+	ErrorUnauthorized:           ProblemTypeBase + "unauthorized",
+	ErrorAdminDisabled:          ProblemTypeBase + "admin-disabled",
+	ErrorInvalidRevertAfter:     ProblemTypeBase + "invalid-revert-after",
+	ErrorInsufficientScope:      ProblemTypeBase + "insufficient-scope",
+	ErrorRoleNotAllowed:         ProblemTypeBase + "role-not-allowed",
+	ErrorReloadPolicy:           ProblemTypeBase + "reload-policy-failed",
+	ErrorRateLimited:            ProblemTypeBase + "rate-limited",
+}
@@ -0,0 +1,94 @@
+package v1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/middlewares"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeys(t *testing.T) {
+	apiKeys, err := models.InitAPIKeyModel(db)
+	assert.Nil(t, err)
+
+	readOnly, readOnlyKey, err := apiKeys.CreateAPIKey(context.Background(), "read only", []string{models.ScopeAppsRead})
+	assert.Nil(t, err)
+	assert.Equal(t, models.HashAPIKey(readOnlyKey), readOnly.KeyHash)
+
+	// Test case 1: Request without an api key
+	t.Run("request without api key", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			SetHeader(middlewares.HeaderAPIKey, "").
+			Get("/api/v1/apps")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode())
+	})
+
+	// Test case 2: Request with an unknown api key
+	t.Run("request with unknown api key", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			SetHeader(middlewares.HeaderAPIKey, "fca_unknown").
+			Get("/api/v1/apps")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode())
+	})
+
+	// Test case 3: Read only key sent as a bearer token can read
+	t.Run("read with read only api key", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			SetAuthToken(readOnlyKey).
+			Get("/api/v1/apps")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+	})
+
+	// Test case 4: Read only key can't write
+	t.Run("write with read only api key", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			SetHeader(middlewares.HeaderAPIKey, readOnlyKey).
+			Delete("/api/v1/apps/1")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode())
+	})
+
+	// Test case 5: Revoked key is rejected
+	t.Run("request with revoked api key", func(t *testing.T) {
+		assert.Nil(t, apiKeys.RevokeAPIKey(context.Background(), readOnly.ID))
+
+		res, err := client.
+			R().
+			EnableTrace().
+			SetHeader(middlewares.HeaderAPIKey, readOnlyKey).
+			Get("/api/v1/apps")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode())
+	})
+
+	// Test case 6: Admin key can use admin endpoints
+	t.Run("admin endpoint with admin api key", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			SetHeader(middlewares.HeaderAPIKey, adminKey).
+			Get("/admin/log-level")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+	})
+}
@@ -3,14 +3,16 @@ package v1
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/models"
+	pMetrics "git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/prometheus"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/structs"
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
 	"github.com/doug-martin/goqu/v9"
-	"github.com/go-playground/validator/v10"
 	"go.uber.org/zap"
 
 	"github.com/gofiber/fiber/v2"
@@ -20,10 +22,11 @@ import (
 type AppController struct {
 	appService *models.AppModel // Use the AppModel directly
 	logger     *zap.Logger
+	pMetrics   *pMetrics.PrometheusMetrics
 }
 
 // NewAppController returns a new AppController
-func NewAppController(goqu *goqu.Database, logger *zap.Logger) (*AppController, error) {
+func NewAppController(goqu *goqu.Database, logger *zap.Logger, pMetrics *pMetrics.PrometheusMetrics) (*AppController, error) {
 	appModel, err := models.InitAppModel(goqu) // Initialize AppModel
 	if err != nil {
 		return nil, err
@@ -32,6 +35,7 @@ func NewAppController(goqu *goqu.Database, logger *zap.Logger) (*AppController,
 	return &AppController{
 		appService: &appModel, // Use the initialized AppModel
 		logger:     logger,
+		pMetrics:   pMetrics,
 	}, nil
 }
 
@@ -55,12 +59,12 @@ func (ac *AppController) GetApp(c *fiber.Ctx) error {
 		return utils.JSONError(c, http.StatusBadRequest, constants.ErrorInvalidAppID)
 	}
 
-	app, err := ac.appService.GetAppById(appID)
+	app, err := ac.appService.GetAppById(c.UserContext(), appID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return utils.JSONFail(c, http.StatusNotFound, constants.ErrorAppNotFound)
 		}
-		ac.logger.Error("error while get app by id", zap.Int("id", appID), zap.Error(err))
+		utils.Logger(c, ac.logger).Error("error while get app by id", zap.Int("id", appID), zap.Error(err))
 		return utils.JSONError(c, http.StatusInternalServerError, constants.FailedToGetApp)
 	}
 	return utils.JSONSuccess(c, http.StatusOK, app)
@@ -85,25 +89,25 @@ func (ac *AppController) GetApps(c *fiber.Ctx) error {
 
 	limit, err := strconv.Atoi(c.Query("limit", strconv.Itoa(constants.DefaultLimit)))
 	if err != nil || limit <= 0 {
-		ac.logger.Error("Invalid limit parameter", zap.String("limit", c.Query("limit")), zap.Error(err))
+		utils.Logger(c, ac.logger).Error("Invalid limit parameter", zap.String("limit", c.Query("limit")), zap.Error(err))
 		return utils.JSONError(c, http.StatusBadRequest, constants.ErrorInvalidLimit)
 	}
 
 	// Check if limit exceeds MaxLimit
 	if limit > MaxLimit {
-		ac.logger.Warn("Requested limit exceeds maximum", zap.Int("limit", limit))
+		utils.Logger(c, ac.logger).Warn("Requested limit exceeds maximum", zap.Int("limit", limit))
 		return utils.JSONError(c, http.StatusBadRequest, constants.ErrorlimitAccess)
 	}
 
 	offset, err := strconv.Atoi(c.Query("offset", strconv.Itoa(constants.DefaultOffset)))
 	if err != nil || offset < 0 {
-		ac.logger.Error("Invalid offset parameter", zap.String("offset", c.Query("offset")), zap.Error(err))
+		utils.Logger(c, ac.logger).Error("Invalid offset parameter", zap.String("offset", c.Query("offset")), zap.Error(err))
 		return utils.JSONError(c, http.StatusBadRequest, constants.ErrorInvalidOffset)
 	}
 
-	apps, err := ac.appService.GetApps(limit, offset)
+	apps, err := ac.appService.GetApps(c.UserContext(), limit, offset)
 	if err != nil {
-		ac.logger.Error("Failed to get apps", zap.Error(err))
+		utils.Logger(c, ac.logger).Error("Failed to get apps", zap.Error(err))
 		return utils.JSONError(c, http.StatusInternalServerError, constants.FailedToGetApp)
 	}
 
@@ -129,26 +133,27 @@ func (ac *AppController) CreateApp(c *fiber.Ctx) error {
 	// Parse the request body into the App struct.
 	err := json.Unmarshal(c.Body(), &appReq)
 	if err != nil {
-		ac.logger.Error("Error unmarshalling request body", zap.Error(err))
+		utils.Logger(c, ac.logger).Error("Error unmarshalling request body", zap.Error(err))
 		return utils.JSONError(c, http.StatusBadRequest, constants.ErrorInvalidRequestBody+err.Error())
 	}
 
 	// Validate the request body.
-	validate := validator.New()
-	err = validate.Struct(appReq)
+	err = utils.Validator.Struct(appReq)
 	if err != nil {
-		ac.logger.Error("Validation error", zap.Error(err))
-		validationErrors := utils.ValidatorErrorString(err)
-		return utils.JSONError(c, http.StatusBadRequest, validationErrors) //  Adapt this as needed.  Send the validation errors.
+		utils.Logger(c, ac.logger).Error("Validation error", zap.Error(err))
+		return utils.JSONValidationFail(c, err)
 	}
 
 	// Insert the app data into the database.
-	insertedApp, err := ac.appService.InsertApps(appReq)
+	insertedApp, err := ac.appService.InsertApps(c.UserContext(), appReq)
 	if err != nil {
-		ac.logger.Error("Error inserting app data", zap.Error(err))
+		utils.Logger(c, ac.logger).Error("Error inserting app data", zap.Error(err))
 		return utils.JSONError(c, http.StatusInternalServerError, constants.ErrorFiledToCreateApp) //Use a constant
 	}
 
+	ac.pMetrics.CountCatalogChange(pMetrics.EntityApp, pMetrics.ActionCreated, 1)
+	utils.Logger(c, ac.logger).Info("App created", zap.Int("id", insertedApp.AppId), zap.String("user_id", utils.UserID(c)))
+
 	// Return the newly created app data, including the generated ID.
 	return utils.JSONSuccess(c, http.StatusCreated, insertedApp)
 }
@@ -170,20 +175,22 @@ func (ac *AppController) CreateApp(c *fiber.Ctx) error {
 func (ac *AppController) DeleteApp(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params(constants.ParamAppID))
 	if err != nil {
-		ac.logger.Error("Error parsing app ID", zap.Error(err))
+		utils.Logger(c, ac.logger).Error("Error parsing app ID", zap.Error(err))
 		return utils.JSONError(c, http.StatusBadRequest, constants.ErrorInvalidAppID)
 	}
 
-	err = ac.appService.DeleteApp(id)
+	err = ac.appService.DeleteApp(c.UserContext(), id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ac.logger.Warn("App not found", zap.Int("id", id))
+			utils.Logger(c, ac.logger).Warn("App not found", zap.Int("id", id))
 			return utils.JSONError(c, http.StatusNotFound, constants.ErrorAppNotFound)
 		}
-		ac.logger.Error("Error deleting app", zap.Error(err), zap.Int("id", id))
+		utils.Logger(c, ac.logger).Error("Error deleting app", zap.Error(err), zap.Int("id", id))
 		return utils.JSONError(c, http.StatusInternalServerError, constants.ErrorFaiedToDeleteApp)
 	}
 
+	ac.pMetrics.CountCatalogChange(pMetrics.EntityApp, pMetrics.ActionDeleted, 1)
+	utils.Logger(c, ac.logger).Info("App deleted", zap.Int("id", id), zap.String("user_id", utils.UserID(c)))
 	return utils.JSONSuccess(c, http.StatusOK, constants.AppsDeletedSuccessfully)
 }
 
@@ -205,7 +212,7 @@ func (ac *AppController) DeleteApp(c *fiber.Ctx) error {
 func (ac *AppController) UpdateApp(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params(constants.ParamAppID))
 	if err != nil {
-		ac.logger.Error("Error parsing app ID", zap.Error(err))
+		utils.Logger(c, ac.logger).Error("Error parsing app ID", zap.Error(err))
 		return utils.JSONError(c, http.StatusBadRequest, constants.ErrorInvalidAppID)
 	}
 
@@ -215,21 +222,104 @@ func (ac *AppController) UpdateApp(c *fiber.Ctx) error {
 	}
 
 	// Validate the request body.
-	validate := validator.New()
-	err = validate.Struct(updatedApp)
+	err = utils.Validator.Struct(updatedApp)
 	if err != nil {
-		return utils.JSONError(c, http.StatusBadRequest, utils.ValidatorErrorString(err)) //  Adapt this as needed
+		utils.Logger(c, ac.logger).Error("Validation error", zap.Error(err))
+		return utils.JSONValidationFail(c, err)
 	}
 
-	updatedApp, err = ac.appService.UpdateApp(id, updatedApp)
+	updatedApp, err = ac.appService.UpdateApp(c.UserContext(), id, updatedApp)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ac.logger.Warn("App not found", zap.Int("id", id))
+			utils.Logger(c, ac.logger).Warn("App not found", zap.Int("id", id))
 			return utils.JSONError(c, http.StatusNotFound, constants.ErrorAppNotFound)
 		}
-		ac.logger.Error("Error updating app", zap.Error(err), zap.Int("id", id))
+		utils.Logger(c, ac.logger).Error("Error updating app", zap.Error(err), zap.Int("id", id))
 		return utils.JSONError(c, http.StatusInternalServerError, constants.ErrorFiledToUpdateApp)
 	}
 
+	ac.pMetrics.CountCatalogChange(pMetrics.EntityApp, pMetrics.ActionUpdated, 1)
+	utils.Logger(c, ac.logger).Info("App updated", zap.Int("id", id), zap.String("user_id", utils.UserID(c)))
 	return utils.JSONSuccess(c, http.StatusOK, updatedApp)
 }
+
+// GetDuplicates lists groups of duplicate apps.
+//
+//	@Summary		Get Duplicate Apps
+//	@Description	Detects apps sharing a category and an exact or similar name.
+//	@Tags			Apps
+//	@Accept			json
+//	@Produce		json
+//	@Param			match	query	string	false	"Match mode: exact (default) or fuzzy"
+//	@Success		200	{array}	models.DuplicateGroup
+//	@Failure		400	{object}	utils.JSONResponse
+//	@Failure		500	{object}	utils.JSONResponse
+//	@Router			/api/v1/apps/duplicates [get]
+
+func (ac *AppController) GetDuplicates(c *fiber.Ctx) error {
+	match := c.Query(constants.ParamMatch, models.DuplicateMatchExact)
+	if match != models.DuplicateMatchExact && match != models.DuplicateMatchFuzzy {
+		return utils.JSONFail(c, http.StatusBadRequest, constants.ErrorInvalidMatch)
+	}
+
+	groups, err := ac.appService.GetDuplicateApps(c.UserContext(), match)
+	if err != nil {
+		utils.Logger(c, ac.logger).Error("Failed to get duplicate apps", zap.String("match", match), zap.Error(err))
+		return utils.JSONError(c, http.StatusInternalServerError, constants.FailedToGetDuplicates)
+	}
+
+	return utils.JSONSuccess(c, http.StatusOK, groups)
+}
+
+// MergeApps merges duplicate apps into the app with the given ID.
+//
+//	@Summary		Merge Duplicate Apps
+//	@Description	Keeps the app, re-points reviews of the duplicates to it and deletes the duplicates in one transaction.
+//	@Tags			Apps
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	int					true	"Canonical App ID"
+//	@Param			merge	body	structs.MergeApps	true	"Duplicate app IDs"
+//	@Success		200	{object}	models.MergeResult
+//	@Failure		400	{object}	utils.JSONResponse
+//	@Failure		404	{object}	utils.JSONResponse
+//	@Failure		500	{object}	utils.JSONResponse
+//	@Router			/api/v1/apps/{id}/merge [post]
+
+func (ac *AppController) MergeApps(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params(constants.ParamAppID))
+	if err != nil {
+		utils.Logger(c, ac.logger).Error("Error parsing app ID", zap.Error(err))
+		return utils.JSONError(c, http.StatusBadRequest, constants.ErrorInvalidAppID)
+	}
+
+	var mergeReq structs.MergeApps
+	if err := json.Unmarshal(c.Body(), &mergeReq); err != nil {
+		utils.Logger(c, ac.logger).Error("Error unmarshalling request body", zap.Error(err))
+		return utils.JSONError(c, http.StatusBadRequest, constants.ErrorInvalidRequestBody)
+	}
+
+	err = utils.Validator.Struct(mergeReq)
+	if err != nil {
+		utils.Logger(c, ac.logger).Error("Validation error", zap.Error(err))
+		return utils.JSONValidationFail(c, err)
+	}
+
+	result, err := ac.appService.MergeApps(c.UserContext(), id, mergeReq.DuplicateIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return utils.JSONFail(c, http.StatusNotFound, constants.ErrorAppNotFound)
+		case errors.Is(err, models.ErrMergeAppNotFound):
+			return utils.JSONFail(c, http.StatusNotFound, constants.ErrorDuplicateAppNotFound)
+		case errors.Is(err, models.ErrInvalidMerge):
+			return utils.JSONFail(c, http.StatusBadRequest, constants.ErrorInvalidMerge)
+		}
+		utils.Logger(c, ac.logger).Error("Error merging duplicate apps", zap.Error(err), zap.Int("id", id))
+		return utils.JSONError(c, http.StatusInternalServerError, constants.ErrorFailedToMergeApps)
+	}
+
+	ac.pMetrics.CountCatalogChange(pMetrics.EntityApp, pMetrics.ActionDeleted, len(result.DeletedIDs))
+	utils.Logger(c, ac.logger).Info("Duplicate apps merged", zap.Int("id", id), zap.Ints("deleted_ids", result.DeletedIDs), zap.String("user_id", utils.UserID(c)))
+	return utils.JSONSuccess(c, http.StatusOK, result)
+}
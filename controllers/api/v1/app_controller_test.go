@@ -1,13 +1,50 @@
 package v1_test
 
 import (
+	"fmt"
 	"net/http"
 	"testing"
 
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/middlewares"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/models"
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/structs"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
+	"github.com/doug-martin/goqu/v9"
 	"github.com/stretchr/testify/assert"
 )
 
+// testApp returns a valid request for an app named name in category
+func testApp(name, category string) structs.App {
+	return structs.App{
+		App:           name,
+		Category:      category,
+		Rating:        4.5,
+		Reviews:       1000,
+		Size:          "15MB",
+		Installs:      "50000",
+		Type:          "Free",
+		Price:         "0",
+		ContentRating: "Everyone",
+		Genres:        "Tools",
+		LastUpdated:   "2025-05-14",
+		CurrentVer:    "1.0.0",
+		AndroidVer:    "5.0 and up",
+	}
+}
+
+// insertTestApp creates an app named name in category and returns its ID
+func insertTestApp(t *testing.T, name, category string) int {
+	t.Helper()
+	var created struct {
+		Data models.App `json:"data"`
+	}
+	res, err := client.R().SetBody(testApp(name, category)).SetResult(&created).Post("/api/v1/apps")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, res.StatusCode())
+	return created.Data.AppId
+}
+
 func TestCreateApp(t *testing.T) {
 	// Test case 1: Create app with invalid input (missing required fields)
 	t.Run("create app with invalid input", func(t *testing.T) {
@@ -150,6 +187,7 @@ func TestDeleteApp(t *testing.T) {
 		res, err := client.
 			R().
 			EnableTrace().
+			SetHeader(middlewares.HeaderAPIKey, adminKey).
 			Delete("/api/v1/apps/2")
 
 		assert.Nil(t, err)
@@ -161,6 +199,7 @@ func TestDeleteApp(t *testing.T) {
 		res, err := client.
 			R().
 			EnableTrace().
+			SetHeader(middlewares.HeaderAPIKey, adminKey).
 			Delete("/api/v1/apps/99999") // Non-existent ID
 
 		assert.Nil(t, err)
@@ -250,3 +289,300 @@ func TestUpdateApp(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, res.StatusCode())
 	})
 }
+func TestDuplicateApps(t *testing.T) {
+	ids := []int{
+		insertTestApp(t, "MyDuplicateTestApp", "Utilities"),
+		insertTestApp(t, "MyDuplicateTestApp", "Utilities"),
+	}
+
+	// Test case 1: Exact duplicates are detected
+	t.Run("get exact duplicates", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			Get("/api/v1/apps/duplicates")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+		assert.Contains(t, res.String(), "MyDuplicateTestApp")
+	})
+
+	// Test case 2: Invalid match mode
+	t.Run("get duplicates with invalid match", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			Get("/api/v1/apps/duplicates?match=sometimes")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode())
+	})
+
+	// Test case 3: Canonical app listed as its own duplicate
+	t.Run("merge app into itself", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			SetBody(structs.MergeApps{DuplicateIDs: []int{ids[0]}}).
+			SetHeader(middlewares.HeaderAPIKey, adminKey).
+			Post(fmt.Sprintf("/api/v1/apps/%d/merge", ids[0]))
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode())
+	})
+
+	// Test case 4: Merge duplicates into the canonical app
+	t.Run("merge duplicates", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			SetBody(structs.MergeApps{DuplicateIDs: ids[1:]}).
+			SetHeader(middlewares.HeaderAPIKey, adminKey).
+			Post(fmt.Sprintf("/api/v1/apps/%d/merge", ids[0]))
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+
+		res, err = client.R().Get(fmt.Sprintf("/api/v1/apps/%d", ids[1]))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode())
+	})
+
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM apps WHERE app='MyDuplicateTestApp'")
+		assert.Nil(t, err)
+	})
+}
+func TestFuzzyDuplicateApps(t *testing.T) {
+	names := []string{
+		"Fuzzy Tenth", "fuzzy-tentx", // 1 edit in 10 runes, exactly the threshold
+		"Fuzzy Nine", "Fuzzy Ninx", // 1 edit in 9 runes, just below it
+		"Fuzzy Lengthy", "Fuzzy Lengthys", // lengths close enough to be compared
+		"Fuzzy Longname", "Fuzzy Longname Plus", // lengths too far apart to be compared
+	}
+	for _, name := range names {
+		insertTestApp(t, name, "FuzzyTestCategory")
+	}
+
+	var body struct {
+		Data []models.DuplicateGroup `json:"data"`
+	}
+	res, err := client.R().SetResult(&body).Get("/api/v1/apps/duplicates?match=fuzzy")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode())
+
+	// groupOf returns the names of the apps grouped with name
+	groupOf := func(name string) []string {
+		for _, group := range body.Data {
+			members := []string{group.Canonical.App}
+			for _, duplicate := range group.Duplicates {
+				members = append(members, duplicate.App)
+			}
+			for _, member := range members {
+				if member == name {
+					return members
+				}
+			}
+		}
+		return nil
+	}
+
+	// Test case 1: Names exactly at the similarity threshold are grouped
+	t.Run("similarity at threshold", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"Fuzzy Tenth", "fuzzy-tentx"}, groupOf("Fuzzy Tenth"))
+	})
+
+	// Test case 2: Names just below the similarity threshold are not grouped
+	t.Run("similarity below threshold", func(t *testing.T) {
+		assert.Nil(t, groupOf("Fuzzy Nine"))
+		assert.Nil(t, groupOf("Fuzzy Ninx"))
+	})
+
+	// Test case 3: Names of a similar length are compared and grouped
+	t.Run("similar length", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"Fuzzy Lengthy", "Fuzzy Lengthys"}, groupOf("Fuzzy Lengthy"))
+	})
+
+	// Test case 4: Names too different in length are never grouped
+	t.Run("length too different", func(t *testing.T) {
+		assert.Nil(t, groupOf("Fuzzy Longname"))
+		assert.Nil(t, groupOf("Fuzzy Longname Plus"))
+	})
+
+	// Test case 5: Exact matching only groups equal names
+	t.Run("exact match ignores similar names", func(t *testing.T) {
+		res, err := client.R().SetResult(&body).Get("/api/v1/apps/duplicates?match=exact")
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+		assert.Nil(t, groupOf("Fuzzy Tenth"))
+	})
+
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM apps WHERE category='FuzzyTestCategory'")
+		assert.Nil(t, err)
+	})
+}
+func TestMergeAppsRepointsReviews(t *testing.T) {
+	countReviews := func(app string) int64 {
+		count, err := db.From("reviews").Where(goqu.Ex{"app": app}).Count()
+		assert.Nil(t, err)
+		return count
+	}
+
+	canonical := insertTestApp(t, "Merge Keep", "MergeTestCategory")
+	gone := insertTestApp(t, "Merge Gone", "MergeTestCategory")
+	shared := insertTestApp(t, "Merge Shared", "MergeTestCategory")
+	// Still uses the name "Merge Shared" after the merge
+	insertTestApp(t, "Merge Shared", "MergeOtherCategory")
+
+	_, err := db.Exec(`INSERT INTO reviews (app, translated_review, sentiment) VALUES
+		('Merge Gone', 'Great app!', 'Positive'),
+		('Merge Gone', 'Nice', 'Positive'),
+		('Merge Shared', 'Bad app', 'Negative')`)
+	assert.Nil(t, err)
+
+	var body struct {
+		Data models.MergeResult `json:"data"`
+	}
+	res, err := client.
+		R().
+		EnableTrace().
+		SetBody(structs.MergeApps{DuplicateIDs: []int{gone, shared}}).
+		SetResult(&body).
+		SetHeader(middlewares.HeaderAPIKey, adminKey).
+		Post(fmt.Sprintf("/api/v1/apps/%d/merge", canonical))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode())
+
+	// Test case 1: Reviews of names no remaining app uses are re-pointed
+	t.Run("reviews of unused names are re-pointed", func(t *testing.T) {
+		assert.Equal(t, int64(2), body.Data.ReviewsRepointed)
+		assert.Equal(t, int64(2), countReviews("Merge Keep"))
+		assert.Equal(t, int64(0), countReviews("Merge Gone"))
+	})
+
+	// Test case 2: Reviews of names still used by another app are kept
+	t.Run("reviews of names still in use are kept", func(t *testing.T) {
+		assert.Equal(t, int64(1), countReviews("Merge Shared"))
+	})
+
+	// Test case 3: Duplicates are deleted
+	t.Run("duplicates are deleted", func(t *testing.T) {
+		assert.ElementsMatch(t, []int{gone, shared}, body.Data.DeletedIDs)
+	})
+
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM reviews WHERE app IN ('Merge Keep', 'Merge Gone', 'Merge Shared')")
+		assert.Nil(t, err)
+		_, err = db.Exec("DELETE FROM apps WHERE category IN ('MergeTestCategory', 'MergeOtherCategory')")
+		assert.Nil(t, err)
+	})
+}
+func TestCreateAppDomainValidation(t *testing.T) {
+	// Test case 1: A rating and review count of 0 are legitimate
+	t.Run("create app with zero rating and reviews", func(t *testing.T) {
+		req := testApp("MyValidationTestApp", "Utilities")
+		req.Rating = 0
+		req.Reviews = 0
+
+		res, err := client.R().EnableTrace().SetBody(req).Post("/api/v1/apps")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, res.StatusCode())
+	})
+
+	// Test case 2: Rating outside 0-5
+	t.Run("create app with out of range rating", func(t *testing.T) {
+		req := testApp("MyValidationTestApp", "Utilities")
+		req.Rating = 42
+
+		var body struct {
+			Status string                      `json:"status"`
+			Data   map[string]utils.FieldError `json:"data"`
+		}
+		res, err := client.R().EnableTrace().SetBody(req).SetError(&body).Post("/api/v1/apps")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode())
+		assert.Equal(t, "fail", body.Status)
+		assert.Equal(t, utils.ValidationCodeTooLarge, body.Data["rating"].Code)
+	})
+
+	// Test case 3: Free app with a price
+	t.Run("create free app with price", func(t *testing.T) {
+		req := testApp("MyValidationTestApp", "Utilities")
+		req.Price = "$2.99"
+
+		res, err := client.R().EnableTrace().SetBody(req).Post("/api/v1/apps")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode())
+		assert.Contains(t, res.String(), "price")
+	})
+
+	// Test case 4: Unknown content rating
+	t.Run("create app with unknown content rating", func(t *testing.T) {
+		req := testApp("MyValidationTestApp", "Utilities")
+		req.ContentRating = "Toddlers"
+
+		res, err := client.R().EnableTrace().SetBody(req).Post("/api/v1/apps")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode())
+		assert.Contains(t, res.String(), "content_rating")
+	})
+
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM apps WHERE app='MyValidationTestApp'")
+		assert.Nil(t, err)
+	})
+}
+func TestProblemJSONErrors(t *testing.T) {
+	// Test case 1: jsend stays the default error format
+	t.Run("get missing app as jsend", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			Get("/api/v1/apps/99999")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode())
+		assert.Contains(t, res.Header().Get("Content-Type"), "application/json")
+	})
+
+	// Test case 2: Clients can opt into RFC 7807 responses
+	t.Run("get missing app as problem+json", func(t *testing.T) {
+		var problem utils.Problem
+		res, err := client.
+			R().
+			EnableTrace().
+			SetHeader("Accept", utils.MIMEApplicationProblemJSON).
+			SetHeader(constants.HeaderRequestID, "problem-test").
+			SetError(&problem).
+			Get("/api/v1/apps/99999")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode())
+		assert.Contains(t, res.Header().Get("Content-Type"), utils.MIMEApplicationProblemJSON)
+		assert.Equal(t, constants.ProblemTypes[constants.ErrorAppNotFound], problem.Type)
+		assert.Equal(t, http.StatusNotFound, problem.Status)
+		assert.Equal(t, "problem-test", problem.Instance)
+	})
+
+	// Test case 3: Validation problems list invalid fields as an extension member
+	t.Run("create invalid app as problem+json", func(t *testing.T) {
+		var problem utils.Problem
+		res, err := client.
+			R().
+			EnableTrace().
+			SetHeader("Accept", utils.MIMEApplicationProblemJSON).
+			SetBody(structs.App{App: "MyTestApp"}).
+			SetError(&problem).
+			Post("/api/v1/apps")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode())
+		assert.Equal(t, constants.ProblemTypeValidation, problem.Type)
+		assert.Contains(t, problem.Errors, "category")
+	})
+}
@@ -1,55 +1,127 @@
 package v1
 
 import (
-	"context"
+	"errors"
 	"net/http"
 
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/database"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/health"
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
-	"github.com/doug-martin/goqu/v9"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
 
+// drainingCheck is reported by the readiness probe while shutting down
+const drainingCheck = "draining"
+
+// errNoDatabaseCheck is logged when /healthz/db finds no database check to run
+var errNoDatabaseCheck = errors.New("database health check is not registered")
+
 type HealthController struct {
-	db     *goqu.Database
+	checks *health.Registry
+	state  *health.State
 	logger *zap.Logger
 }
 
-func NewHealthController(db *goqu.Database, logger *zap.Logger) (*HealthController, error) {
+func NewHealthController(checks *health.Registry, state *health.State, logger *zap.Logger) (*HealthController, error) {
 	return &HealthController{
-		db:     db,
+		checks: checks,
+		state:  state,
 		logger: logger,
 	}, nil
 }
 
+// Live reports that the process is running, without checking dependencies
+// @Summary Liveness probe
+// @Description Succeeds while the process is able to serve requests at all. Dependencies are not checked, so a database outage doesn't get the process restarted.
+// @Tags Healthcheck
+// @ID livenessProbe
+// @Produce json
+// @Success 200 {object} utils.JSONResponse "Process is alive"
+// @Router /livez [get]
+func (hc *HealthController) Live(ctx *fiber.Ctx) error {
+	return utils.JSONSuccess(ctx, http.StatusOK, health.Report{Status: health.StatusOK})
+}
+
+// Ready reports whether the process should receive traffic
+// @Summary Readiness probe
+// @Description Succeeds while every critical check passes and the process is not shutting down. Failing non-critical checks report the status degraded. The result of each check is listed in the body.
+// @Tags Healthcheck
+// @ID readinessProbe
+// @Produce json
+// @Param verbose query bool false "Report latency, check time and last error of each check"
+// @Success 200 {object} utils.JSONResponse "Ready to receive traffic"
+// @Failure 503 {object} utils.JSONResponse "Not ready, see the failing checks"
+// @Router /readyz [get]
+func (hc *HealthController) Ready(ctx *fiber.Ctx) error {
+	report := hc.runChecks(ctx)
+	draining := health.Result{Status: health.StatusOK, Critical: true}
+	if hc.state.Draining() {
+		draining.Status = health.StatusFail
+		report.Status = health.StatusFail
+	}
+	report.Checks[drainingCheck] = draining
+	return hc.respond(ctx, report)
+}
+
+// Started reports whether the process finished starting
+// @Summary Startup probe
+// @Description Runs the checks until the critical ones pass once, and succeeds from then on, so liveness and readiness probes only start once the process could serve.
+// @Tags Healthcheck
+// @ID startupProbe
+// @Produce json
+// @Param verbose query bool false "Report latency, check time and last error of each check"
+// @Success 200 {object} utils.JSONResponse "Started"
+// @Failure 503 {object} utils.JSONResponse "Still starting, see the failing checks"
+// @Router /startupz [get]
+func (hc *HealthController) Started(ctx *fiber.Ctx) error {
+	if hc.state.Started() {
+		return utils.JSONSuccess(ctx, http.StatusOK, health.Report{Status: health.StatusOK})
+	}
+	return hc.respond(ctx, hc.runChecks(ctx))
+}
+
+// runChecks runs every registered check. The process counts as started once
+// the critical checks passed.
+func (hc *HealthController) runChecks(ctx *fiber.Ctx) health.Report {
+	report := hc.checks.Run(ctx.UserContext())
+	if report.Status != health.StatusFail {
+		hc.state.MarkStarted()
+	}
+	return report
+}
+
+// respond writes report, with the check details when verbose is requested
+func (hc *HealthController) respond(ctx *fiber.Ctx, report health.Report) error {
+	for name, result := range report.Checks {
+		if result.Status == health.StatusFail && result.LastError != "" {
+			utils.Logger(ctx, hc.logger).Warn("health check failed",
+				zap.String("check", name), zap.Bool("critical", result.Critical), zap.String("error", result.LastError))
+		}
+	}
+	if !ctx.QueryBool(constants.ParamVerbose) {
+		report = report.Brief()
+	}
+
+	if report.Status == health.StatusFail {
+		return utils.JSONFail(ctx, http.StatusServiceUnavailable, report)
+	}
+	return utils.JSONSuccess(ctx, http.StatusOK, report)
+}
+
 // Overall check overall health of application as well as dependencies health check
 // @Summary Overall health check
-// @Description Overall health check of application as well as dependencies health check
+// @Description Overall health check of application as well as dependencies health check. Runs every registered check like the readiness probe, without taking draining into account.
 // @Tags Healthcheck
 // @ID overallHealthCheck
 // @Produce json
+// @Param verbose query bool false "Report latency, check time and last error of each check"
 // @Success 200 {object} utils.JSONResponse "Health check successful"
-// @Failure 500 {object} utils.JSONResponse "Internal server error during health check"
+// @Failure 503 {object} utils.JSONResponse "A critical check failed, see the failing checks"
 // @Router /healthz [get]
 func (hc *HealthController) Overall(ctx *fiber.Ctx) error {
-	// --- Add this log to see if the handler is being reached ---
-	hc.logger.Info("Received request for /healthz")
-	// --- End Add ---
-
-	err := healthDb(hc.db) // This checks the database
-	if err != nil {
-		// --- Add this log to see if the DB check failed ---
-		hc.logger.Error("Database health check failed", zap.Error(err))
-		// --- End Add ---
-		return utils.JSONError(ctx, http.StatusInternalServerError, constants.ErrHealthCheckDb)
-	}
-
-	// --- Add this log to confirm success ---
-	hc.logger.Info("Database health check successful")
-	// --- End Add ---
-
-	return utils.JSONSuccess(ctx, http.StatusOK, "ok")
+	return hc.respond(ctx, hc.runChecks(ctx))
 }
 
 // Self health check
@@ -66,31 +138,20 @@ func (hc *HealthController) Self(ctx *fiber.Ctx) error {
 
 // Database health check
 // @Summary Database health check
-// @Description Database health check
+// @Description Runs the database check registered for the probes
 // @Tags Healthcheck
 // @ID dbHealthCheck
 // @Produce json
+// @Param verbose query bool false "Report latency, check time and last error of the check"
 // @Success 200 {object} utils.JSONResponse "Database health check successful"
-// @Failure 500 {object} utils.JSONResponse "Internal server error during database health check"
+// @Failure 500 {object} utils.JSONResponse "No database check is registered"
+// @Failure 503 {object} utils.JSONResponse "Database health check failed"
 // @Router /healthz/db [get]
 func (hc *HealthController) Db(ctx *fiber.Ctx) error {
-	err := healthDb(hc.db)
-	if err != nil {
-		hc.logger.Error("error while health checking of db", zap.Error(err))
+	report, ok := hc.checks.RunCheck(ctx.UserContext(), database.HealthCheckDatabase)
+	if !ok {
+		utils.Logger(ctx, hc.logger).Error("error while health checking of db", zap.String("check", database.HealthCheckDatabase), zap.Error(errNoDatabaseCheck))
 		return utils.JSONError(ctx, http.StatusInternalServerError, constants.ErrHealthCheckDb)
 	}
-	return utils.JSONSuccess(ctx, http.StatusOK, "ok")
-}
-
-///////////////////////
-// HealthCheck CORE
-//////////////////////
-
-func healthDb(db *goqu.Database) error {
-	// Reverted to original implementation
-	_, err := db.ExecContext(context.TODO(), "SELECT 1")
-	if err != nil {
-		return err
-	}
-	return nil
+	return hc.respond(ctx, report)
 }
@@ -0,0 +1,156 @@
+package v1_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	v1 "git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/controllers/api/v1"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/database"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/health"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// probeResponse is a JSend response carrying a probe report
+type probeResponse struct {
+	Status string        `json:"status"`
+	Data   health.Report `json:"data"`
+}
+
+// TestProbes tests the liveness, readiness and startup probes
+func TestProbes(t *testing.T) {
+	// Test case 1: Liveness doesn't check dependencies
+	t.Run("livez", func(t *testing.T) {
+		res, err := client.R().EnableTrace().Get("/livez")
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+
+		var body probeResponse
+		assert.Nil(t, json.Unmarshal(res.Body(), &body))
+		assert.Equal(t, health.StatusOK, body.Data.Status)
+		assert.Empty(t, body.Data.Checks)
+	})
+
+	// Test case 2: Readiness lists every check
+	t.Run("readyz", func(t *testing.T) {
+		res, err := client.R().EnableTrace().Get("/readyz")
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+
+		var body probeResponse
+		assert.Nil(t, json.Unmarshal(res.Body(), &body))
+		assert.Equal(t, health.StatusOK, body.Data.Status)
+		for _, check := range []string{"database", "migrations", "draining"} {
+			assert.Equal(t, health.StatusOK, body.Data.Checks[check].Status, check)
+		}
+	})
+
+	// Test case 3: Verbose mode reports check details
+	t.Run("readyz verbose", func(t *testing.T) {
+		res, err := client.R().EnableTrace().SetQueryParam("verbose", "true").Get("/readyz")
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+
+		var body probeResponse
+		assert.Nil(t, json.Unmarshal(res.Body(), &body))
+		database := body.Data.Checks["database"]
+		assert.True(t, database.Critical)
+		assert.NotEmpty(t, database.Latency)
+		assert.NotNil(t, database.CheckedAt)
+		assert.Empty(t, database.LastError)
+	})
+
+	// Test case 4: Startup succeeds once dependencies are reachable
+	t.Run("startupz", func(t *testing.T) {
+		res, err := client.R().EnableTrace().Get("/startupz")
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+	})
+
+	// Test case 5: Self check is routed
+	t.Run("healthz self", func(t *testing.T) {
+		res, err := client.R().EnableTrace().Get("/healthz/self")
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+	})
+
+	// Test case 6: Overall health runs every check
+	t.Run("healthz", func(t *testing.T) {
+		res, err := client.R().EnableTrace().Get("/healthz")
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+
+		var body probeResponse
+		assert.Nil(t, json.Unmarshal(res.Body(), &body))
+		assert.Equal(t, health.StatusOK, body.Data.Status)
+		for _, check := range []string{database.HealthCheckDatabase, database.HealthCheckMigrations} {
+			assert.Equal(t, health.StatusOK, body.Data.Checks[check].Status, check)
+		}
+	})
+
+	// Test case 7: Database health runs the database check only
+	t.Run("healthz db", func(t *testing.T) {
+		res, err := client.R().EnableTrace().SetQueryParam("verbose", "true").Get("/healthz/db")
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+
+		var body probeResponse
+		assert.Nil(t, json.Unmarshal(res.Body(), &body))
+		assert.Equal(t, health.StatusOK, body.Data.Status)
+		assert.Len(t, body.Data.Checks, 1)
+		assert.NotEmpty(t, body.Data.Checks[database.HealthCheckDatabase].Latency)
+	})
+}
+
+// TestDraining tests the probes of a process that is shutting down
+func TestDraining(t *testing.T) {
+	checks := health.NewRegistry(config.HealthConfig{CheckTimeout: time.Second})
+	assert.Nil(t, checks.Register(health.Check{
+		Name:     database.HealthCheckDatabase,
+		Critical: true,
+		Checker:  health.CheckerFunc(func(context.Context) error { return nil }),
+	}))
+	state := &health.State{}
+	healthController, err := v1.NewHealthController(checks, state, zap.NewNop())
+	assert.Nil(t, err)
+
+	app := fiber.New()
+	app.Get("/livez", healthController.Live)
+	app.Get("/readyz", healthController.Ready)
+
+	probe := func(path string) (int, probeResponse) {
+		res, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Nil(t, err)
+		var body probeResponse
+		assert.Nil(t, json.NewDecoder(res.Body).Decode(&body))
+		return res.StatusCode, body
+	}
+
+	// Test case 1: Ready until draining starts
+	t.Run("ready", func(t *testing.T) {
+		status, body := probe("/readyz")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, health.StatusOK, body.Data.Checks["draining"].Status)
+	})
+
+	// Test case 2: Draining fails readiness, but the process stays alive
+	t.Run("draining", func(t *testing.T) {
+		state.Drain()
+
+		status, body := probe("/readyz")
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+		assert.Equal(t, health.StatusFail, body.Data.Status)
+		assert.Equal(t, health.StatusFail, body.Data.Checks["draining"].Status)
+		assert.Equal(t, health.StatusOK, body.Data.Checks[database.HealthCheckDatabase].Status)
+
+		status, body = probe("/livez")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, health.StatusOK, body.Data.Status)
+	})
+}
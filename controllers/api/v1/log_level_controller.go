@@ -0,0 +1,120 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/logger"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/structs"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LogLevelController reads and changes log levels at runtime
+type LogLevelController struct {
+	levels      *logger.Levels
+	revertAfter time.Duration
+	logger      *zap.Logger
+}
+
+// NewLogLevelController returns a new LogLevelController. Levels changed
+// without a duration are reverted after revertAfter.
+func NewLogLevelController(levels *logger.Levels, revertAfter time.Duration, logger *zap.Logger) (*LogLevelController, error) {
+	if levels == nil {
+		return nil, errors.New("log levels are not initialized, the root logger was not built by NewRootLogger")
+	}
+	return &LogLevelController{
+		levels:      levels,
+		revertAfter: revertAfter,
+		logger:      logger,
+	}, nil
+}
+
+// GetLogLevel returns the current log levels.
+//
+//	@Summary		Get Log Levels
+//	@Description	Returns the level of the root logger and the overrides of named loggers.
+//	@Tags			Admin
+//	@Produce		json
+//	@Success		200	{object}	logger.LevelsState
+//	@Failure		401	{object}	utils.JSONResponse
+//	@Router			/admin/log-level [get]
+func (lc *LogLevelController) GetLogLevel(c *fiber.Ctx) error {
+	return utils.JSONSuccess(c, http.StatusOK, lc.levels.State())
+}
+
+// SetLogLevel changes the level of the root logger or of a named logger
+// until it is reverted.
+//
+//	@Summary		Set Log Level
+//	@Description	Changes a log level for revert_after, or the configured default duration.
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			level	body		structs.LogLevel	true	"Log level"
+//	@Success		200		{object}	logger.LevelsState
+//	@Failure		400		{object}	utils.JSONResponse
+//	@Failure		401		{object}	utils.JSONResponse
+//	@Router			/admin/log-level [put]
+func (lc *LogLevelController) SetLogLevel(c *fiber.Ctx) error {
+	var logLevel structs.LogLevel
+	if err := json.Unmarshal(c.Body(), &logLevel); err != nil {
+		utils.Logger(c, lc.logger).Error("Error parsing request body", zap.Error(err))
+		return utils.JSONFail(c, http.StatusBadRequest, constants.ErrorInvalidRequestBody)
+	}
+
+	if err := utils.Validator.Struct(logLevel); err != nil {
+		utils.Logger(c, lc.logger).Error("Validation error", zap.Error(err))
+		return utils.JSONValidationFail(c, err)
+	}
+
+	revertAfter := lc.revertAfter
+	if logLevel.RevertAfter != "" {
+		duration, err := time.ParseDuration(logLevel.RevertAfter)
+		if err != nil || duration <= 0 {
+			return utils.JSONFail(c, http.StatusBadRequest, constants.ErrorInvalidRevertAfter)
+		}
+		revertAfter = duration
+	}
+
+	level, err := zapcore.ParseLevel(logLevel.Level)
+	if err != nil {
+		return utils.JSONFail(c, http.StatusBadRequest, constants.ErrorInvalidRequestBody)
+	}
+
+	lc.levels.SetLevel(logLevel.Logger, level, revertAfter)
+	utils.Logger(c, lc.logger).Info("log level changed",
+		zap.String("logger", logLevel.Logger),
+		zap.String("level", level.String()),
+		zap.Duration("revert_after", revertAfter),
+	)
+	return utils.JSONSuccess(c, http.StatusOK, lc.levels.State())
+}
+
+// RevertLogLevel restores the default level of the logger named by the
+// logger query parameter, or of every logger when it is not given.
+//
+//	@Summary		Revert Log Level
+//	@Description	Restores the default level of one or all loggers.
+//	@Tags			Admin
+//	@Produce		json
+//	@Param			logger	query		string	false	"Logger name"
+//	@Success		200		{object}	logger.LevelsState
+//	@Failure		401		{object}	utils.JSONResponse
+//	@Router			/admin/log-level [delete]
+func (lc *LogLevelController) RevertLogLevel(c *fiber.Ctx) error {
+	name := c.Query(constants.ParamLogger)
+	if name == "" {
+		lc.levels.Reset()
+	} else {
+		lc.levels.Revert(name)
+	}
+
+	utils.Logger(c, lc.logger).Info("log level reverted", zap.String("logger", name))
+	return utils.JSONSuccess(c, http.StatusOK, lc.levels.State())
+}
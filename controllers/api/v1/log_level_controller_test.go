@@ -0,0 +1,100 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/logger"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/structs"
+	"github.com/stretchr/testify/assert"
+)
+
+const adminToken = "testing-admin-token"
+
+func TestLogLevel(t *testing.T) {
+	// Test case 1: Request from a caller that isn't an admin
+	t.Run("get log level without admin credentials", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			Get("/admin/log-level")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode())
+	})
+
+	// Test case 2: Request with a wrong admin token
+	t.Run("get log level with wrong token", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			SetAuthToken("wrong-token").
+			Get("/admin/log-level")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode())
+	})
+
+	// Test case 3: Change the level of a named logger
+	t.Run("set named log level", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			SetAuthToken(adminToken).
+			SetBody(structs.LogLevel{Level: "debug", Logger: "http", RevertAfter: "1m"}).
+			Put("/admin/log-level")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+
+		var body struct {
+			Data logger.LevelsState `json:"data"`
+		}
+		assert.Nil(t, json.Unmarshal(res.Body(), &body))
+		assert.Equal(t, "debug", body.Data.Named["http"])
+		assert.Contains(t, body.Data.RevertAt, "http")
+	})
+
+	// Test case 4: Invalid level and revert duration
+	t.Run("set invalid log level", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			SetAuthToken(adminToken).
+			SetBody(structs.LogLevel{Level: "verbose"}).
+			Put("/admin/log-level")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode())
+
+		res, err = client.
+			R().
+			EnableTrace().
+			SetAuthToken(adminToken).
+			SetBody(structs.LogLevel{Level: "debug", RevertAfter: "soon"}).
+			Put("/admin/log-level")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode())
+	})
+
+	// Test case 5: Revert every level
+	t.Run("revert log levels", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			SetAuthToken(adminToken).
+			Delete("/admin/log-level")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+
+		var body struct {
+			Data logger.LevelsState `json:"data"`
+		}
+		assert.Nil(t, json.Unmarshal(res.Body(), &body))
+		assert.Empty(t, body.Data.Named)
+		assert.Empty(t, body.Data.RevertAt)
+	})
+}
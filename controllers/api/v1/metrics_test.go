@@ -0,0 +1,75 @@
+package v1_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMetrics tests GET /metrics
+func TestMetrics(t *testing.T) {
+	t.Run("requests are counted", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			Get("/api/v1/apps/99999")
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode())
+
+		res, err = client.
+			R().
+			EnableTrace().
+			Get("/metrics")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+		assert.Contains(t, res.String(), `golang_api_requests_total{code="4xx"}`)
+	})
+
+	t.Run("latency is labeled by route template", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			Get("/metrics")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+		assert.Contains(t, res.String(), `golang_api_request_duration_seconds_count{method="GET",route="/api/v1/apps/:appID"}`)
+		assert.Contains(t, res.String(), "golang_api_requests_in_flight")
+		assert.Contains(t, res.String(), "golang_api_response_size_bytes")
+	})
+
+	t.Run("database pool and queries are measured", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			Get("/api/v1/apps?limit=1")
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+
+		res, err = client.
+			R().
+			EnableTrace().
+			Get("/metrics")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+		assert.Contains(t, res.String(), `golang_api_db_query_duration_seconds_count{operation="apps.list"}`)
+		assert.Contains(t, res.String(), "go_sql_open_connections")
+		assert.Contains(t, res.String(), "go_sql_wait_count_total")
+	})
+
+	t.Run("catalog metrics are exported", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			Get("/metrics")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+		assert.Contains(t, res.String(), "golang_api_catalog_apps ")
+		assert.Contains(t, res.String(), "golang_api_catalog_reviews ")
+		assert.Contains(t, res.String(), "golang_api_catalog_average_rating{")
+	})
+}
@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/rbac"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// RBACController reads and reloads the rbac policy at runtime
+type RBACController struct {
+	policy *rbac.PolicyFile
+	logger *zap.Logger
+}
+
+// NewRBACController returns a new RBACController
+func NewRBACController(policy *rbac.PolicyFile, logger *zap.Logger) (*RBACController, error) {
+	if policy == nil {
+		return nil, errors.New("rbac policy is not loaded")
+	}
+	return &RBACController{
+		policy: policy,
+		logger: logger,
+	}, nil
+}
+
+// GetPolicy returns the rbac policy in effect.
+//
+//	@Summary		Get RBAC Policy
+//	@Description	Returns the roles allowed per route and method.
+//	@Tags			Admin
+//	@Produce		json
+//	@Success		200	{object}	rbac.Policy
+//	@Failure		401	{object}	utils.JSONResponse
+//	@Router			/admin/rbac/policy [get]
+func (rc *RBACController) GetPolicy(c *fiber.Ctx) error {
+	return utils.JSONSuccess(c, http.StatusOK, rc.policy.Policy())
+}
+
+// ReloadPolicy reads the policy file again, the current policy is kept when
+// the file is invalid.
+//
+//	@Summary		Reload RBAC Policy
+//	@Description	Reads the rbac policy file again without restarting.
+//	@Tags			Admin
+//	@Produce		json
+//	@Success		200	{object}	rbac.Policy
+//	@Failure		401	{object}	utils.JSONResponse
+//	@Failure		500	{object}	utils.JSONResponse
+//	@Router			/admin/rbac/reload [post]
+func (rc *RBACController) ReloadPolicy(c *fiber.Ctx) error {
+	if err := rc.policy.Reload(); err != nil {
+		utils.Logger(c, rc.logger).Error("error while reloading rbac policy", zap.String("path", rc.policy.Path()), zap.Error(err))
+		return utils.JSONError(c, http.StatusInternalServerError, constants.ErrorReloadPolicy)
+	}
+
+	utils.Logger(c, rc.logger).Info("Reloaded rbac policy", zap.String("path", rc.policy.Path()), zap.String("user_id", utils.UserID(c)))
+	return utils.JSONSuccess(c, http.StatusOK, rc.policy.Policy())
+}
@@ -0,0 +1,78 @@
+package v1_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/middlewares"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/models"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// viewerToken is mapped to the viewer role by RBAC_TOKENS in .env.testing
+const viewerToken = "testing-viewer-token"
+
+func TestRBAC(t *testing.T) {
+	apiKeys, err := models.InitAPIKeyModel(db)
+	assert.Nil(t, err)
+	_, editorKey, err := apiKeys.CreateAPIKey(context.Background(), "editor", []string{models.ScopeAppsRead, models.ScopeAppsWrite, models.ScopeReviewsWrite})
+	assert.Nil(t, err)
+
+	// Test case 1: Viewer token can read
+	t.Run("viewer reads apps", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			SetAuthToken(viewerToken).
+			Get("/api/v1/apps")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+	})
+
+	// Test case 2: Viewer token can't create
+	t.Run("viewer creates app", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			SetAuthToken(viewerToken).
+			SetBody(map[string]any{}).
+			Post("/api/v1/apps")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode())
+	})
+
+	// Test case 3: Editor can't delete, the policy only allows admins
+	t.Run("editor deletes app", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			SetHeader(middlewares.HeaderAPIKey, editorKey).
+			Delete("/api/v1/apps/1")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode())
+
+		var body utils.JSONResponse
+		assert.Nil(t, json.Unmarshal(res.Body(), &body))
+		assert.Equal(t, "fail", body.Status)
+		assert.Equal(t, constants.ErrorRoleNotAllowed, body.Data)
+	})
+
+	// Test case 4: Policy is reloaded by an admin
+	t.Run("reload policy", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			SetAuthToken(adminToken).
+			Post("/admin/rbac/reload")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+	})
+}
@@ -0,0 +1,67 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRequestID tests X-Request-ID handling
+func TestRequestID(t *testing.T) {
+	t.Run("request ID is generated", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			Get("/api/v1/apps?limit=1")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+		assert.NotEmpty(t, res.Header().Get(constants.HeaderRequestID))
+	})
+
+	t.Run("client request ID is returned", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			SetHeader(constants.HeaderRequestID, "client-request-1").
+			Get("/api/v1/apps?limit=1")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+		assert.Equal(t, "client-request-1", res.Header().Get(constants.HeaderRequestID))
+	})
+
+	t.Run("invalid request ID is replaced", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			SetHeader(constants.HeaderRequestID, strings.Repeat("a", constants.MaxRequestIDLength+1)).
+			Get("/api/v1/apps?limit=1")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+		assert.NotEmpty(t, res.Header().Get(constants.HeaderRequestID))
+		assert.NotEqual(t, strings.Repeat("a", constants.MaxRequestIDLength+1), res.Header().Get(constants.HeaderRequestID))
+	})
+
+	t.Run("error body contains request ID", func(t *testing.T) {
+		res, err := client.
+			R().
+			EnableTrace().
+			SetHeader(constants.HeaderRequestID, "client-request-2").
+			Get("/api/v1/apps/99999")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode())
+
+		var body utils.JSONResponse
+		assert.Nil(t, json.Unmarshal(res.Body(), &body))
+		assert.Equal(t, "fail", body.Status)
+		assert.Equal(t, "client-request-2", body.RequestID)
+	})
+}
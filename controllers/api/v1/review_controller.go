@@ -8,9 +8,9 @@ import (
 
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants" // Import your constants
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/models"
+	pMetrics "git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/prometheus"
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
 	"github.com/doug-martin/goqu/v9"
-	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
@@ -19,10 +19,11 @@ import (
 type ReviewController struct {
 	reviewService *models.ReviewModel
 	logger        *zap.Logger
+	pMetrics      *pMetrics.PrometheusMetrics
 }
 
 // NewReviewController returns a new ReviewController
-func NewReviewController(goqu *goqu.Database, logger *zap.Logger) (*ReviewController, error) {
+func NewReviewController(goqu *goqu.Database, logger *zap.Logger, pMetrics *pMetrics.PrometheusMetrics) (*ReviewController, error) {
 	reviewModel, err := models.InitReviewModel(goqu)
 	if err != nil {
 		return nil, err
@@ -31,6 +32,7 @@ func NewReviewController(goqu *goqu.Database, logger *zap.Logger) (*ReviewContro
 	return &ReviewController{
 		reviewService: &reviewModel,
 		logger:        logger,
+		pMetrics:      pMetrics,
 	}, nil
 }
 
@@ -53,23 +55,23 @@ func (rc *ReviewController) GetReviews(c *fiber.Ctx) error {
 
 	limit, err := strconv.Atoi(c.Query("limit", strconv.Itoa(constants.DefaultLimit)))
 	if err != nil || limit <= 0 {
-		rc.logger.Error("Invalid limit parameter", zap.String("limit", c.Query("limit")), zap.Error(err))
+		utils.Logger(c, rc.logger).Error("Invalid limit parameter", zap.String("limit", c.Query("limit")), zap.Error(err))
 		return utils.JSONError(c, http.StatusBadRequest, constants.ErrorInvalidLimit)
 	}
 	if limit > MaxLimit {
-		rc.logger.Warn("Requested limit exceeds maximum", zap.Int("limit", limit))
+		utils.Logger(c, rc.logger).Warn("Requested limit exceeds maximum", zap.Int("limit", limit))
 		return utils.JSONError(c, http.StatusBadRequest, constants.ErrorlimitAccess)
 	}
 
 	offset, err := strconv.Atoi(c.Query("offset", strconv.Itoa(constants.DefaultOffset)))
 	if err != nil || offset < 0 {
-		rc.logger.Error("Invalid offset parameter", zap.String("offset", c.Query("offset")), zap.Error(err))
+		utils.Logger(c, rc.logger).Error("Invalid offset parameter", zap.String("offset", c.Query("offset")), zap.Error(err))
 		return utils.JSONError(c, http.StatusBadRequest, constants.ErrorInvalidOffset)
 	}
 
-	reviews, err := rc.reviewService.GetReviews(limit, offset)
+	reviews, err := rc.reviewService.GetReviews(c.UserContext(), limit, offset)
 	if err != nil {
-		rc.logger.Error("Failed to get reviews", zap.Error(err))
+		utils.Logger(c, rc.logger).Error("Failed to get reviews", zap.Error(err))
 		return utils.JSONError(c, http.StatusInternalServerError, constants.FailedToGetReviews)
 	}
 
@@ -96,12 +98,12 @@ func (rc *ReviewController) GetReview(c *fiber.Ctx) error {
 		return utils.JSONError(c, http.StatusBadRequest, constants.ErrorInvalidReviewID)
 	}
 
-	review, err := rc.reviewService.GetReviewById(reviewID)
+	review, err := rc.reviewService.GetReviewById(c.UserContext(), reviewID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return utils.JSONFail(c, http.StatusNotFound, constants.ErrorReviewNotFound)
 		}
-		rc.logger.Error("error while get review by id", zap.Int("id", reviewID), zap.Error(err))
+		utils.Logger(c, rc.logger).Error("error while get review by id", zap.Int("id", reviewID), zap.Error(err))
 		return utils.JSONError(c, http.StatusInternalServerError, constants.FailedToGetReview)
 	}
 	return utils.JSONSuccess(c, http.StatusOK, review)
@@ -125,15 +127,14 @@ func (rc *ReviewController) CreateReviewData(c *fiber.Ctx) error {
 
 	err := json.Unmarshal(c.Body(), &reviewReq)
 	if err != nil {
-		rc.logger.Error("Error unmarshalling request body", zap.Error(err))
+		utils.Logger(c, rc.logger).Error("Error unmarshalling request body", zap.Error(err))
 		return utils.JSONError(c, http.StatusBadRequest, constants.ErrorInvalidRequestBody)
 	}
 
-	validate := validator.New()
-	err = validate.Struct(reviewReq)
+	err = utils.Validator.Struct(reviewReq)
 	if err != nil {
-		rc.logger.Error("Validation error", zap.Error(err))
-		return utils.JSONError(c, http.StatusBadRequest, utils.ValidatorErrorString(err))
+		utils.Logger(c, rc.logger).Error("Validation error", zap.Error(err))
+		return utils.JSONValidationFail(c, err)
 	}
 
 	reviewToInsert := models.Review{
@@ -144,12 +145,14 @@ func (rc *ReviewController) CreateReviewData(c *fiber.Ctx) error {
 		SentimentSubjectivity: reviewReq.SentimentSubjectivity,
 	}
 
-	insertedReview, err := rc.reviewService.InsertReviews(reviewToInsert)
+	insertedReview, err := rc.reviewService.InsertReviews(c.UserContext(), reviewToInsert)
 	if err != nil {
-		rc.logger.Error("Error inserting review data", zap.Error(err))
+		utils.Logger(c, rc.logger).Error("Error inserting review data", zap.Error(err))
 		return utils.JSONError(c, http.StatusInternalServerError, constants.ErrorFiledToCreateReviewApp)
 	}
 
+	rc.pMetrics.CountCatalogChange(pMetrics.EntityReview, pMetrics.ActionCreated, 1)
+	utils.Logger(c, rc.logger).Info("Review created", zap.Int("id", insertedReview.ReviewID), zap.String("user_id", utils.UserID(c)))
 	return utils.JSONSuccess(c, http.StatusCreated, insertedReview)
 }
 
@@ -170,20 +173,22 @@ func (rc *ReviewController) CreateReviewData(c *fiber.Ctx) error {
 func (rc *ReviewController) DeleteReview(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params(constants.ParamReviewID))
 	if err != nil {
-		rc.logger.Error("Error parsing review ID", zap.Error(err))
+		utils.Logger(c, rc.logger).Error("Error parsing review ID", zap.Error(err))
 		return utils.JSONError(c, http.StatusBadRequest, constants.ErrorInvalidReviewID)
 	}
 
-	err = rc.reviewService.DeleteApp(id)
+	err = rc.reviewService.DeleteApp(c.UserContext(), id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			rc.logger.Warn("Review not found", zap.Int("id", id))
+			utils.Logger(c, rc.logger).Warn("Review not found", zap.Int("id", id))
 			return utils.JSONError(c, http.StatusNotFound, constants.ErrorReviewNotFound)
 		}
-		rc.logger.Error("Error deleting review", zap.Error(err), zap.Int("id", id))
+		utils.Logger(c, rc.logger).Error("Error deleting review", zap.Error(err), zap.Int("id", id))
 		return utils.JSONError(c, http.StatusInternalServerError, constants.ErrorFaiedToDeleteReview)
 	}
 
+	rc.pMetrics.CountCatalogChange(pMetrics.EntityReview, pMetrics.ActionDeleted, 1)
+	utils.Logger(c, rc.logger).Info("Review deleted", zap.Int("id", id), zap.String("user_id", utils.UserID(c)))
 	return utils.JSONSuccess(c, http.StatusOK, constants.ReviewsDeletedSuccessfully)
 }
 
@@ -204,33 +209,34 @@ func (rc *ReviewController) DeleteReview(c *fiber.Ctx) error {
 func (rc *ReviewController) UpdateReview(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params(constants.ParamReviewID))
 	if err != nil {
-		rc.logger.Error("Error parsing review ID", zap.Error(err))
+		utils.Logger(c, rc.logger).Error("Error parsing review ID", zap.Error(err))
 		return utils.JSONError(c, http.StatusBadRequest, constants.ErrorInvalidReviewID)
 	}
 
 	var updatedReview models.Review
 	if err := json.Unmarshal(c.Body(), &updatedReview); err != nil {
-		rc.logger.Error("Error parsing request body", zap.Error(err))
+		utils.Logger(c, rc.logger).Error("Error parsing request body", zap.Error(err))
 		return utils.JSONError(c, http.StatusBadRequest, constants.ErrorInvalidRequestBody)
 	}
 
 	// Validate the request body.
-	validate := validator.New()
-	err = validate.Struct(updatedReview)
+	err = utils.Validator.Struct(updatedReview)
 	if err != nil {
-		rc.logger.Error("Validation error", zap.Error(err))
-		return utils.JSONError(c, http.StatusBadRequest, utils.ValidatorErrorString(err)) //  Adapt this as needed
+		utils.Logger(c, rc.logger).Error("Validation error", zap.Error(err))
+		return utils.JSONValidationFail(c, err)
 	}
 
-	updatedReview, err = rc.reviewService.UpdateReview(id, updatedReview)
+	updatedReview, err = rc.reviewService.UpdateReview(c.UserContext(), id, updatedReview)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			rc.logger.Warn("Review not found", zap.Int("id", id))
+			utils.Logger(c, rc.logger).Warn("Review not found", zap.Int("id", id))
 			return utils.JSONError(c, http.StatusNotFound, constants.ErrorReviewNotFound)
 		}
-		rc.logger.Error("Error updating review", zap.Error(err), zap.Int("id", id))
+		utils.Logger(c, rc.logger).Error("Error updating review", zap.Error(err), zap.Int("id", id))
 		return utils.JSONError(c, http.StatusInternalServerError, constants.FailedToUpdateReviews)
 	}
 
+	rc.pMetrics.CountCatalogChange(pMetrics.EntityReview, pMetrics.ActionUpdated, 1)
+	utils.Logger(c, rc.logger).Info("Review updated", zap.Int("id", id), zap.String("user_id", utils.UserID(c)))
 	return utils.JSONSuccess(c, http.StatusOK, updatedReview)
 }
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"testing"
 
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/middlewares"
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/structs"
 	"github.com/stretchr/testify/assert"
 )
@@ -29,7 +30,7 @@ func TestCreateReview(t *testing.T) {
 		req := structs.Review{
 			App:                   "MyTestApp",
 			TranslatedReview:      "Great app!",
-			Sentiment:             "positive",
+			Sentiment:             "Positive",
 			SentimentPolarity:     structs.NullableFloat64{Float64: 0.9, Valid: true},
 			SentimentSubjectivity: structs.NullableFloat64{Float64: 0.1, Valid: true},
 		}
@@ -44,6 +45,42 @@ func TestCreateReview(t *testing.T) {
 		assert.Equal(t, http.StatusCreated, res.StatusCode())
 	})
 
+	t.Run("create review with unknown sentiment", func(t *testing.T) {
+		req := structs.Review{
+			App:              "MyTestApp",
+			TranslatedReview: "Great app!",
+			Sentiment:        "Ecstatic",
+		}
+
+		res, err := client.
+			R().
+			EnableTrace().
+			SetBody(req).
+			Post("/api/v1/reviews")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode())
+	})
+
+	t.Run("create review with out of range polarity", func(t *testing.T) {
+		req := structs.Review{
+			App:                   "MyTestApp",
+			TranslatedReview:      "Great app!",
+			Sentiment:             "Positive",
+			SentimentPolarity:     structs.NullableFloat64{Float64: 1.5, Valid: true},
+			SentimentSubjectivity: structs.NullableFloat64{Float64: 0.1, Valid: true},
+		}
+
+		res, err := client.
+			R().
+			EnableTrace().
+			SetBody(req).
+			Post("/api/v1/reviews")
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode())
+	})
+
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM reviews WHERE app = 'MyTestApp'")
 		assert.Nil(t, err)
@@ -113,7 +150,7 @@ func TestUpdateReview(t *testing.T) {
 		req := structs.Review{
 			App:                   "UpdatedApp",
 			TranslatedReview:      "Updated review text",
-			Sentiment:             "neutral",
+			Sentiment:             "Neutral",
 			SentimentPolarity:     structs.NullableFloat64{Float64: 0.0, Valid: true},
 			SentimentSubjectivity: structs.NullableFloat64{Float64: 0.5, Valid: true},
 		}
@@ -132,7 +169,7 @@ func TestUpdateReview(t *testing.T) {
 		req := structs.Review{
 			App:              "", // missing required app name
 			TranslatedReview: "Review text",
-			Sentiment:        "neutral",
+			Sentiment:        "Neutral",
 		}
 
 		res, err := client.
@@ -149,7 +186,7 @@ func TestUpdateReview(t *testing.T) {
 		req := structs.Review{
 			App:                   "UpdatedApp",
 			TranslatedReview:      "Updated review text",
-			Sentiment:             "neutral",
+			Sentiment:             "Neutral",
 			SentimentPolarity:     structs.NullableFloat64{Float64: 0.0, Valid: true},
 			SentimentSubjectivity: structs.NullableFloat64{Float64: 0.5, Valid: true},
 		}
@@ -171,6 +208,7 @@ func TestDeleteReview(t *testing.T) {
 		res, err := client.
 			R().
 			EnableTrace().
+			SetHeader(middlewares.HeaderAPIKey, adminKey).
 			Delete("/api/v1/reviews/2")
 
 		assert.Nil(t, err)
@@ -181,6 +219,7 @@ func TestDeleteReview(t *testing.T) {
 		res, err := client.
 			R().
 			EnableTrace().
+			SetHeader(middlewares.HeaderAPIKey, adminKey).
 			Delete("/api/v1/reviews/99999")
 
 		assert.Nil(t, err)
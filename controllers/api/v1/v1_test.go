@@ -1,6 +1,7 @@
 package v1_test
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -12,6 +13,8 @@ import (
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/database"
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/logger"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/middlewares"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/models"
 	"github.com/doug-martin/goqu/v9"
 	"github.com/go-resty/resty/v2"
 	"go.uber.org/zap"
@@ -20,6 +23,9 @@ import (
 var client *resty.Client = nil
 var db *goqu.Database = nil
 
+// adminKey is an api key with the admin scope, for the requests only admins may make
+var adminKey string
+
 func TestMain(m *testing.M) {
 	err := os.Chdir("../../../")
 	if err != nil {
@@ -27,7 +33,7 @@ func TestMain(m *testing.M) {
 	}
 
 	cfg := config.LoadTestEnv()
-	logger, err := logger.NewRootLogger(true, true)
+	logger, err := logger.NewRootLogger(true, true, cfg.Logging)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -51,6 +57,21 @@ func TestMain(m *testing.M) {
 		logger.Fatal("error while execute migration", zap.Error(err))
 	}
 
+	// Authenticate test requests as an editor, admin requests set adminKey
+	apiKeys, err := models.InitAPIKeyModel(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	_, key, err := apiKeys.CreateAPIKey(context.Background(), "integration tests", []string{models.ScopeAppsRead, models.ScopeAppsWrite, models.ScopeReviewsWrite})
+	if err != nil {
+		logger.Fatal("error while creating api key", zap.Error(err))
+	}
+	client.SetHeader(middlewares.HeaderAPIKey, key)
+	_, adminKey, err = apiKeys.CreateAPIKey(context.Background(), "integration tests admin", []string{models.ScopeAdmin})
+	if err != nil {
+		logger.Fatal("error while creating api key", zap.Error(err))
+	}
+
 	go func() {
 		err = cmd.Execute()
 		if err != nil {
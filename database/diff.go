@@ -0,0 +1,136 @@
+package database
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/doug-martin/goqu/v9"
+)
+
+// ratingTolerance absorbs the float rounding introduced by the REAL rating column.
+const ratingTolerance = 0.0001
+
+// AppFieldChange describes one field that differs between the CSV and the database.
+type AppFieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// AppDiffEntry is an app identified by its natural key (app name + category).
+type AppDiffEntry struct {
+	App      string           `json:"app"`
+	Category string           `json:"category"`
+	Changes  []AppFieldChange `json:"changes,omitempty"`
+}
+
+// AppDiff is the result of comparing an app_data CSV against the apps table.
+type AppDiff struct {
+	Added   []AppDiffEntry `json:"added"`
+	Removed []AppDiffEntry `json:"removed"`
+	Changed []AppDiffEntry `json:"changed"`
+}
+
+// appSnapshot holds the fields of an app that take part in the diff.
+type appSnapshot struct {
+	App        string  `db:"app"`
+	Category   string  `db:"category"`
+	Rating     float64 `db:"rating"`
+	Installs   string  `db:"installs"`
+	CurrentVer string  `db:"current_ver"`
+}
+
+func (s appSnapshot) key() string {
+	return s.App + "\x00" + s.Category
+}
+
+// DiffAppData compares the app_data CSV at csvPath with the apps table.
+// Apps are matched on app name and category; when the same key appears more
+// than once, the first occurrence on each side is used.
+func DiffAppData(csvPath string, db *goqu.Database, warn io.Writer) (AppDiff, error) {
+	rows, err := readAppCSV(csvPath, warn)
+	if err != nil {
+		return AppDiff{}, err
+	}
+
+	incoming := make([]appSnapshot, 0, len(rows))
+	for _, row := range rows {
+		incoming = append(incoming, appSnapshot{
+			App:        row["app"].(string),
+			Category:   row["category"].(string),
+			Rating:     row["rating"].(float64),
+			Installs:   row["installs"].(string),
+			CurrentVer: row["current_ver"].(string),
+		})
+	}
+
+	var existing []appSnapshot
+	err = db.From("apps").
+		Select("app", "category", goqu.COALESCE(goqu.C("rating"), 0).As("rating"), "installs", "current_ver").
+		Order(goqu.C("id").Asc()).
+		ScanStructs(&existing)
+	if err != nil {
+		return AppDiff{}, fmt.Errorf("failed to load apps: %w", err)
+	}
+
+	return diffApps(existing, incoming), nil
+}
+
+func diffApps(existing, incoming []appSnapshot) AppDiff {
+	diff := AppDiff{
+		Added:   []AppDiffEntry{},
+		Removed: []AppDiffEntry{},
+		Changed: []AppDiffEntry{},
+	}
+
+	current := make(map[string]appSnapshot, len(existing))
+	for _, app := range existing {
+		if _, ok := current[app.key()]; !ok {
+			current[app.key()] = app
+		}
+	}
+
+	seen := make(map[string]bool, len(incoming))
+	for _, app := range incoming {
+		if seen[app.key()] {
+			continue
+		}
+		seen[app.key()] = true
+
+		old, ok := current[app.key()]
+		if !ok {
+			diff.Added = append(diff.Added, AppDiffEntry{App: app.App, Category: app.Category})
+			continue
+		}
+
+		var changes []AppFieldChange
+		if math.Abs(old.Rating-app.Rating) > ratingTolerance {
+			changes = append(changes, AppFieldChange{
+				Field: "rating",
+				Old:   strconv.FormatFloat(old.Rating, 'f', -1, 32),
+				New:   strconv.FormatFloat(app.Rating, 'f', -1, 32),
+			})
+		}
+		if old.Installs != app.Installs {
+			changes = append(changes, AppFieldChange{Field: "installs", Old: old.Installs, New: app.Installs})
+		}
+		if old.CurrentVer != app.CurrentVer {
+			changes = append(changes, AppFieldChange{Field: "current_ver", Old: old.CurrentVer, New: app.CurrentVer})
+		}
+		if len(changes) > 0 {
+			diff.Changed = append(diff.Changed, AppDiffEntry{App: app.App, Category: app.Category, Changes: changes})
+		}
+	}
+
+	for _, app := range existing {
+		if seen[app.key()] {
+			continue
+		}
+		seen[app.key()] = true
+		diff.Removed = append(diff.Removed, AppDiffEntry{App: app.App, Category: app.Category})
+	}
+
+	return diff
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDiffApps tests comparing CSV apps with the apps table
+func TestDiffApps(t *testing.T) {
+	maps := appSnapshot{App: "Maps", Category: "TRAVEL", Rating: 4.1, Installs: "1,000+", CurrentVer: "1.0"}
+	chat := appSnapshot{App: "Chat", Category: "SOCIAL", Rating: 3.5, Installs: "500+", CurrentVer: "2.0"}
+
+	tests := []struct {
+		name     string
+		existing []appSnapshot
+		incoming []appSnapshot
+		want     AppDiff
+	}{
+		{
+			// Test case 1: Apps only in the CSV are added
+			name:     "added",
+			existing: []appSnapshot{maps},
+			incoming: []appSnapshot{maps, chat},
+			want: AppDiff{
+				Added:   []AppDiffEntry{{App: "Chat", Category: "SOCIAL"}},
+				Removed: []AppDiffEntry{},
+				Changed: []AppDiffEntry{},
+			},
+		},
+		{
+			// Test case 2: Apps only in the database are removed
+			name:     "removed",
+			existing: []appSnapshot{maps, chat},
+			incoming: []appSnapshot{maps},
+			want: AppDiff{
+				Added:   []AppDiffEntry{},
+				Removed: []AppDiffEntry{{App: "Chat", Category: "SOCIAL"}},
+				Changed: []AppDiffEntry{},
+			},
+		},
+		{
+			// Test case 3: Every differing field of an app is reported
+			name:     "changed",
+			existing: []appSnapshot{maps},
+			incoming: []appSnapshot{{App: "Maps", Category: "TRAVEL", Rating: 4.5, Installs: "5,000+", CurrentVer: "1.1"}},
+			want: AppDiff{
+				Added:   []AppDiffEntry{},
+				Removed: []AppDiffEntry{},
+				Changed: []AppDiffEntry{{App: "Maps", Category: "TRAVEL", Changes: []AppFieldChange{
+					{Field: "rating", Old: "4.1", New: "4.5"},
+					{Field: "installs", Old: "1,000+", New: "5,000+"},
+					{Field: "current_ver", Old: "1.0", New: "1.1"},
+				}}},
+			},
+		},
+		{
+			// Test case 4: Equal apps, and ratings within the rounding tolerance, are unchanged
+			name:     "unchanged",
+			existing: []appSnapshot{maps, chat},
+			incoming: []appSnapshot{{App: "Maps", Category: "TRAVEL", Rating: 4.10000002, Installs: "1,000+", CurrentVer: "1.0"}, chat},
+			want: AppDiff{
+				Added:   []AppDiffEntry{},
+				Removed: []AppDiffEntry{},
+				Changed: []AppDiffEntry{},
+			},
+		},
+		{
+			// Test case 5: Apps are keyed by name and category, the first occurrence wins
+			name:     "natural key",
+			existing: []appSnapshot{maps, {App: "Maps", Category: "TRAVEL", Rating: 1, Installs: "1+", CurrentVer: "0.1"}},
+			incoming: []appSnapshot{maps, {App: "Maps", Category: "AUTO", Rating: 4.1, Installs: "1,000+", CurrentVer: "1.0"}, {App: "Maps", Category: "TRAVEL", Rating: 2}},
+			want: AppDiff{
+				Added:   []AppDiffEntry{{App: "Maps", Category: "AUTO"}},
+				Removed: []AppDiffEntry{},
+				Changed: []AppDiffEntry{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, diffApps(tt.existing, tt.incoming))
+		})
+	}
+}
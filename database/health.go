@@ -0,0 +1,47 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/health"
+	"github.com/doug-martin/goqu/v9"
+)
+
+// Names of the checks registered by RegisterHealthChecks
+const (
+	HealthCheckDatabase   = "database"
+	HealthCheckMigrations = "migrations"
+)
+
+// RegisterHealthChecks registers the critical database checks: the database
+// answers queries and every migration in cfg.MigrationDir was applied
+func RegisterHealthChecks(registry *health.Registry, db *goqu.Database, cfg config.DBConfig) error {
+	return errors.Join(
+		registry.Register(health.Check{
+			Name:     HealthCheckDatabase,
+			Critical: true,
+			Checker: health.CheckerFunc(func(ctx context.Context) error {
+				_, err := db.ExecContext(ctx, "SELECT 1")
+				return err
+			}),
+		}),
+		registry.Register(health.Check{
+			Name:     HealthCheckMigrations,
+			Critical: true,
+			Checker: health.CheckerFunc(func(ctx context.Context) error {
+				pending, err := PendingMigrations(ctx, db, cfg.MigrationDir)
+				if err != nil {
+					return err
+				}
+				if len(pending) > 0 {
+					return fmt.Errorf("pending migrations: %s", strings.Join(pending, ", "))
+				}
+				return nil
+			}),
+		}),
+	)
+}
@@ -0,0 +1,216 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/tracing"
+	"github.com/doug-martin/goqu/v9"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// UnknownOperation labels queries whose context names no operation
+const UnknownOperation = "unknown"
+
+// operationKey is the context key of the operation set by WithOperation
+type operationKey struct{}
+
+// WithOperation names the operation of the queries run with ctx, e.g.
+// "apps.get", for their durations and spans
+func WithOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// Operation returns the operation named by WithOperation, or UnknownOperation
+func Operation(ctx context.Context) string {
+	if operation, ok := ctx.Value(operationKey{}).(string); ok {
+		return operation
+	}
+	return UnknownOperation
+}
+
+// QueryObserver receives the duration of a query and the model operation that issued it
+type QueryObserver func(operation string, duration time.Duration)
+
+// instrumentedConnector opens connections that time and trace every query.
+// Queries are instrumented on the driver connection, so the ones run inside
+// a transaction, which goqu runs on the bare *sql.Tx, are instrumented too.
+type instrumentedConnector struct {
+	dsn     string
+	driver  *instrumentedDriver
+	system  string
+	observe atomic.Pointer[QueryObserver]
+}
+
+// instrumentedDriver lets Instrument find the connector of a pool through
+// sql.DB.Driver
+type instrumentedDriver struct {
+	driver.Driver
+	connector *instrumentedConnector
+}
+
+// OpenDB opens a pool of connections to dsn with the driver registered as
+// driverName. Each query gets a client span with its SQL statement, a child
+// of the span in the query context; Instrument also reports their durations.
+func OpenDB(dialect, driverName, dsn string) (*goqu.Database, error) {
+	// sql.Open doesn't connect, it only looks up the registered driver
+	lookup, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	base := lookup.Driver()
+	if err := lookup.Close(); err != nil {
+		return nil, err
+	}
+
+	connector := &instrumentedConnector{dsn: dsn, system: dialect}
+	connector.driver = &instrumentedDriver{Driver: base, connector: connector}
+	return goqu.New(dialect, sql.OpenDB(connector)), nil
+}
+
+// Instrument reports the queries of a database opened by Connect or OpenDB
+// to observe, labeled by the operation named in their context.
+func Instrument(db *goqu.Database, observe QueryObserver) error {
+	sqlDB, ok := SQLDB(db)
+	if !ok {
+		return errors.New("database can't be instrumented, it has no connection pool")
+	}
+	drv, ok := sqlDB.Driver().(*instrumentedDriver)
+	if !ok {
+		return errors.New("database can't be instrumented, it wasn't opened by OpenDB")
+	}
+	drv.connector.observe.Store(&observe)
+	return nil
+}
+
+// SQLDB returns the *sql.DB underneath a goqu database
+func SQLDB(db *goqu.Database) (*sql.DB, bool) {
+	conn, ok := db.Db.(*sql.DB)
+	return conn, ok
+}
+
+func (c *instrumentedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedConn{Conn: conn, connector: c}, nil
+}
+
+func (c *instrumentedConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// instrumentedConn times and traces the queries of a driver connection. The
+// optional driver interfaces are passed through to the wrapped connection.
+type instrumentedConn struct {
+	driver.Conn
+	connector *instrumentedConnector
+}
+
+func (c *instrumentedConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	done := c.start(ctx, query)
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	done(err)
+	return stmt, err
+}
+
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		// database/sql prepares the statement instead
+		return nil, driver.ErrSkip
+	}
+	done := c.start(ctx, query)
+	result, err := execer.ExecContext(ctx, query, args)
+	done(err)
+	return result, err
+}
+
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		// database/sql prepares the statement instead
+		return nil, driver.ErrSkip
+	}
+	done := c.start(ctx, query)
+	rows, err := queryer.QueryContext(ctx, query, args)
+	done(err)
+	return rows, err
+}
+
+func (c *instrumentedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *instrumentedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *instrumentedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *instrumentedConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func (c *instrumentedConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+// start opens the span of a query; the returned function ends it and
+// observes the duration of the query
+func (c *instrumentedConn) start(ctx context.Context, query string) func(error) {
+	begin := time.Now()
+	operation := Operation(ctx)
+	_, span := tracing.Tracer().Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemKey.String(c.connector.system),
+			semconv.DBOperationName(operation),
+			semconv.DBQueryText(query),
+		),
+	)
+	return func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+		if observe := c.connector.observe.Load(); observe != nil {
+			(*observe)(operation, time.Since(begin))
+		}
+	}
+}
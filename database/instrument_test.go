@@ -0,0 +1,91 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/database"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/doug-martin/goqu/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// appColumns are the columns scanned into models.App
+var appColumns = []string{"id", "app", "category", "rating", "reviews", "size", "installs", "type", "price", "content_rating", "genres", "last_updated", "current_ver", "android_ver"}
+
+func appRow(rows *sqlmock.Rows, id int, name string) *sqlmock.Rows {
+	return rows.AddRow(id, name, "MAPS", 4.5, 10, "10M", "1,000+", "Free", "0", "Everyone", "Maps", "2018-01-01", "1.0", "4.0")
+}
+
+// TestInstrument tests timing the queries of model methods
+func TestInstrument(t *testing.T) {
+	// Test case 1: Queries run in a transaction are observed as the operation
+	// of the model method that issued them
+	t.Run("transaction", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.NewWithDSN("instrument-transaction")
+		assert.Nil(t, err)
+		defer mockDB.Close()
+		db, err := database.OpenDB("postgres", "sqlmock", "instrument-transaction")
+		assert.Nil(t, err)
+
+		var operations []string
+		assert.Nil(t, database.Instrument(db, func(operation string, duration time.Duration) {
+			operations = append(operations, operation)
+		}))
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FROM "apps" WHERE \("id" = 1\)`).
+			WillReturnRows(appRow(sqlmock.NewRows(appColumns), 1, "Maps"))
+		mock.ExpectQuery(`FROM "apps" WHERE \("id" IN \(2\)\)`).
+			WillReturnRows(appRow(sqlmock.NewRows(appColumns), 2, "Maps Old"))
+		mock.ExpectQuery(`SELECT "app" FROM "apps" WHERE \(\("app" IN \('Maps Old'\)\) AND \("id" NOT IN \(2\)\)\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"app"}))
+		mock.ExpectExec(`UPDATE "reviews" SET "app"='Maps' WHERE \("app" IN \('Maps Old'\)\)`).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(`DELETE FROM "apps" WHERE \("id" IN \(2\)\)`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		apps, err := models.InitAppModel(db)
+		assert.Nil(t, err)
+		result, err := apps.MergeApps(context.Background(), 1, []int{2})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), result.ReviewsRepointed)
+		assert.Nil(t, mock.ExpectationsWereMet())
+
+		assert.Equal(t, []string{"apps.merge", "apps.merge", "apps.merge", "apps.merge", "apps.merge"}, operations)
+	})
+
+	// Test case 2: Queries whose context names no operation are observed as unknown
+	t.Run("unknown operation", func(t *testing.T) {
+		mockDB, mock, err := sqlmock.NewWithDSN("instrument-unknown")
+		assert.Nil(t, err)
+		defer mockDB.Close()
+		db, err := database.OpenDB("postgres", "sqlmock", "instrument-unknown")
+		assert.Nil(t, err)
+
+		var operations []string
+		assert.Nil(t, database.Instrument(db, func(operation string, duration time.Duration) {
+			operations = append(operations, operation)
+		}))
+
+		mock.ExpectExec(`SELECT 1`).WillReturnResult(sqlmock.NewResult(0, 0))
+		_, err = db.ExecContext(context.Background(), "SELECT 1")
+		assert.Nil(t, err)
+		mock.ExpectExec(`SELECT 2`).WillReturnResult(sqlmock.NewResult(0, 0))
+		_, err = db.ExecContext(database.WithOperation(context.Background(), "seed.insert"), "SELECT 2")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{database.UnknownOperation, "seed.insert"}, operations)
+	})
+
+	// Test case 3: Only databases opened by OpenDB can be instrumented
+	t.Run("not opened by OpenDB", func(t *testing.T) {
+		mockDB, _, err := sqlmock.New()
+		assert.Nil(t, err)
+		defer mockDB.Close()
+
+		assert.NotNil(t, database.Instrument(goqu.New("postgres", mockDB), func(string, time.Duration) {}))
+	})
+}
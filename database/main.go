@@ -31,11 +31,22 @@ func Connect(cfg config.DBConfig) (*goqu.Database, error) {
 func postgresDBConnection(cfg config.DBConfig) (*goqu.Database, error) {
 	dbURL = "postgres://" + cfg.Username + ":" + cfg.Password + "@" + cfg.Host + ":" + strconv.Itoa(cfg.Port) + "/" + cfg.Db + "?" + cfg.QueryString
 	if db == nil {
-		db, err = sql.Open(POSTGRES, dbURL)
+		conn, err := OpenDB(POSTGRES, POSTGRES, dbURL)
 		if err != nil {
 			return nil, err
 		}
-		return goqu.New(POSTGRES, db), err
+		db = conn.Db.(*sql.DB)
 	}
-	return goqu.New(POSTGRES, db), err
+	return goqu.New(POSTGRES, db), nil
+}
+
+// Close closes the connection pool opened by Connect, the next Connect opens
+// a new one
+func Close() error {
+	if db == nil {
+		return nil
+	}
+	err := db.Close()
+	db = nil
+	return err
 }
@@ -0,0 +1,38 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// MigrationTable is where sql-migrate records applied migrations
+const MigrationTable = "gorp_migrations"
+
+// PendingMigrations returns the IDs of the migrations in dir that were not
+// applied to db yet
+func PendingMigrations(ctx context.Context, db *goqu.Database, dir string) ([]string, error) {
+	migrations, err := migrate.FileMigrationSource{Dir: dir}.FindMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("reading migrations: %w", err)
+	}
+
+	var applied []string
+	if err := db.From(MigrationTable).Select("id").ScanValsContext(ctx, &applied); err != nil {
+		return nil, fmt.Errorf("reading applied migrations: %w", err)
+	}
+	done := make(map[string]bool, len(applied))
+	for _, id := range applied {
+		done[id] = true
+	}
+
+	var pending []string
+	for _, migration := range migrations {
+		if !done[migration.Id] {
+			pending = append(pending, migration.Id)
+		}
+	}
+	return pending, nil
+}
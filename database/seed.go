@@ -3,6 +3,7 @@ package database
 import (
 	"encoding/csv"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -52,16 +53,34 @@ func SeedData(cfg config.AppConfig, db *goqu.Database, logger *zap.Logger) error
 }
 
 func seedAppData(csvPath string, tx *goqu.TxDatabase, logger *zap.Logger) error {
+	appData, err := readAppCSV(csvPath, os.Stdout)
+	if err != nil {
+		return err
+	}
+	for _, data := range appData {
+		logger.Debug("Inserting app row", zap.Any("row", data))
+	}
+
+	_, err = tx.Insert("apps").Rows(appData).Executor().Exec()
+	if err != nil {
+		return fmt.Errorf("failed to insert app_data: %w", err)
+	}
+	return nil
+}
+
+// readAppCSV parses the app_data CSV into rows keyed by apps column name.
+// Malformed rows are reported to warn and skipped.
+func readAppCSV(csvPath string, warn io.Writer) ([]map[string]interface{}, error) {
 	file, err := os.Open(csvPath)
 	if err != nil {
-		return fmt.Errorf("failed to open app_data CSV file: %w", err)
+		return nil, fmt.Errorf("failed to open app_data CSV file: %w", err)
 	}
 	defer file.Close()
 
 	reader := csv.NewReader(file)
 	_, err = reader.Read() // Read the header row and discard it
 	if err != nil {
-		return fmt.Errorf("failed to read app_data CSV header: %w", err)
+		return nil, fmt.Errorf("failed to read app_data CSV header: %w", err)
 	}
 
 	expectedFields := 13
@@ -73,12 +92,12 @@ func seedAppData(csvPath string, tx *goqu.TxDatabase, logger *zap.Logger) error
 			if err.Error() == "EOF" {
 				break
 			}
-			fmt.Printf("Warning: Error reading app_data CSV row at line %d: %v\n", lineNum, err)
+			fmt.Fprintf(warn, "Warning: Error reading app_data CSV row at line %d: %v\n", lineNum, err)
 			continue // Skip rows with read errors other than EOF
 		}
 
 		if len(row) != expectedFields {
-			fmt.Printf("Warning: Skipping app_data row at line %d with %d fields (expected %d): %v\n", lineNum, len(row), expectedFields, row)
+			fmt.Fprintf(warn, "Warning: Skipping app_data row at line %d with %d fields (expected %d): %v\n", lineNum, len(row), expectedFields, row)
 			continue // Skip the current row if the number of fields is wrong
 		}
 
@@ -86,7 +105,7 @@ func seedAppData(csvPath string, tx *goqu.TxDatabase, logger *zap.Logger) error
 		if row[2] != "NaN" {
 			rating, err = strconv.ParseFloat(row[2], 64)
 			if err != nil {
-				fmt.Printf("Warning: Skipping row at line %d due to error parsing Rating: %v - Row: %v\n", lineNum, err, row)
+				fmt.Fprintf(warn, "Warning: Skipping row at line %d due to error parsing Rating: %v - Row: %v\n", lineNum, err, row)
 				continue
 			}
 		} else {
@@ -95,7 +114,7 @@ func seedAppData(csvPath string, tx *goqu.TxDatabase, logger *zap.Logger) error
 
 		reviews, err := strconv.Atoi(strings.ReplaceAll(row[3], ",", ""))
 		if err != nil {
-			fmt.Printf("Warning: Skipping row at line %d due to error parsing Reviews: %v - Row: %v\n", lineNum, err, row)
+			fmt.Fprintf(warn, "Warning: Skipping row at line %d due to error parsing Reviews: %v - Row: %v\n", lineNum, err, row)
 			continue
 		}
 
@@ -115,14 +134,8 @@ func seedAppData(csvPath string, tx *goqu.TxDatabase, logger *zap.Logger) error
 			"android_ver":    row[12],
 		}
 		appData = append(appData, data)
-		fmt.Printf("Debug: Inserting row at line %d: %+v\n", lineNum, data) // Debug log
-	}
-
-	_, err = tx.Insert("apps").Rows(appData).Executor().Exec()
-	if err != nil {
-		return fmt.Errorf("failed to insert app_data: %w", err)
 	}
-	return nil
+	return appData, nil
 }
 
 func seedReviewData(csvPath string, tx *goqu.TxDatabase, logger *zap.Logger) error {
@@ -0,0 +1,130 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// clfTimeFormat is the timestamp layout of the Common Log Format
+const clfTimeFormat = "02/Jan/2006:15:04:05 -0700"
+
+// AccessEntry describes one handled request
+type AccessEntry struct {
+	RemoteAddr string
+	User       string
+	Time       time.Time
+	Method     string
+	URI        string
+	Protocol   string
+	Status     int
+	Size       int
+	Referer    string
+	UserAgent  string
+	Duration   time.Duration
+	RequestID  string
+}
+
+// AccessLogger writes one line per request to the access log
+type AccessLogger struct {
+	logger *zap.Logger
+	format string
+}
+
+// NewAccessLogger returns the access logger configured by cfg, or nil when
+// the access log is disabled. The access log is written by its own zap core,
+// to a rotating file or stdout, and is not affected by the log levels.
+func NewAccessLogger(cfg config.LoggingConfig) (*AccessLogger, error) {
+	var encoder zapcore.Encoder
+	switch cfg.AccessLogFormat {
+	case "":
+		return nil, nil
+	case config.AccessLogFormatCommon, config.AccessLogFormatCombined:
+		// The line is rendered as the message, without any other key
+		encoder = zapcore.NewConsoleEncoder(zapcore.EncoderConfig{
+			MessageKey: "msg",
+			LineEnding: zapcore.DefaultLineEnding,
+		})
+	case config.AccessLogFormatJSON:
+		encoder = zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+			TimeKey:        "ts",
+			MessageKey:     "msg",
+			LineEnding:     zapcore.DefaultLineEnding,
+			EncodeTime:     zapcore.ISO8601TimeEncoder,
+			EncodeDuration: zapcore.StringDurationEncoder,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported access log format %q, expected one of common, combined, json", cfg.AccessLogFormat)
+	}
+
+	sink := zapcore.Lock(os.Stdout)
+	if cfg.AccessLogPath != "" {
+		sink = zapcore.AddSync(newRotatingFile(cfg.AccessLogPath, cfg))
+	}
+
+	return &AccessLogger{
+		logger: zap.New(zapcore.NewCore(encoder, sink, zapcore.DebugLevel)),
+		format: cfg.AccessLogFormat,
+	}, nil
+}
+
+// Log writes entry in the configured format
+func (a *AccessLogger) Log(entry AccessEntry) {
+	switch a.format {
+	case config.AccessLogFormatJSON:
+		a.logger.Info("access",
+			zap.Time("time", entry.Time),
+			zap.String("remote_addr", entry.RemoteAddr),
+			zap.String("user", entry.User),
+			zap.String("method", entry.Method),
+			zap.String("uri", entry.URI),
+			zap.String("protocol", entry.Protocol),
+			zap.Int("status", entry.Status),
+			zap.Int("size", entry.Size),
+			zap.String("referer", entry.Referer),
+			zap.String("user_agent", entry.UserAgent),
+			zap.Duration("duration", entry.Duration),
+			zap.String("request_id", entry.RequestID),
+		)
+	case config.AccessLogFormatCombined:
+		a.logger.Info(commonLogLine(entry) + fmt.Sprintf(" %q %q", entry.Referer, entry.UserAgent))
+	default:
+		a.logger.Info(commonLogLine(entry))
+	}
+}
+
+// Sync flushes buffered access log lines
+func (a *AccessLogger) Sync() error {
+	return a.logger.Sync()
+}
+
+// commonLogLine renders entry in the Common Log Format:
+// host ident authuser [date] "request" status bytes
+func commonLogLine(entry AccessEntry) string {
+	size := "-"
+	if entry.Size > 0 {
+		size = strconv.Itoa(entry.Size)
+	}
+	return fmt.Sprintf("%s - %s [%s] \"%s %s %s\" %d %s",
+		entry.RemoteAddr,
+		orDash(entry.User),
+		entry.Time.Format(clfTimeFormat),
+		entry.Method,
+		entry.URI,
+		entry.Protocol,
+		entry.Status,
+		size,
+	)
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAccessLogger tests the lines written to the access log in each format
+func TestAccessLogger(t *testing.T) {
+	entry := AccessEntry{
+		RemoteAddr: "192.0.2.10",
+		User:       "user-1",
+		Time:       time.Date(2024, time.March, 5, 14, 7, 9, 0, time.FixedZone("", 5*60*60+30*60)),
+		Method:     "GET",
+		URI:        "/api/v1/apps?limit=1",
+		Protocol:   "HTTP/1.1",
+		Status:     200,
+		Size:       512,
+		Referer:    "https://example.com/",
+		UserAgent:  `curl/8.0 "quoted"`,
+		Duration:   1500 * time.Microsecond,
+		RequestID:  "request-1",
+	}
+
+	// zap adds the time of logging to JSON lines
+	jsonTimestamp := regexp.MustCompile(`"ts":"[^"]+",`)
+
+	tests := []struct {
+		name   string
+		format string
+		entry  AccessEntry
+		want   string
+	}{
+		{
+			// Test case 1: Common Log Format
+			name:   "common",
+			format: config.AccessLogFormatCommon,
+			entry:  entry,
+			want:   `192.0.2.10 - user-1 [05/Mar/2024:14:07:09 +0530] "GET /api/v1/apps?limit=1 HTTP/1.1" 200 512` + "\n",
+		},
+		{
+			// Test case 2: Combined Log Format adds the quoted referer and user agent
+			name:   "combined",
+			format: config.AccessLogFormatCombined,
+			entry:  entry,
+			want:   `192.0.2.10 - user-1 [05/Mar/2024:14:07:09 +0530] "GET /api/v1/apps?limit=1 HTTP/1.1" 200 512 "https://example.com/" "curl/8.0 \"quoted\""` + "\n",
+		},
+		{
+			// Test case 3: JSON has a key per field
+			name:   "json",
+			format: config.AccessLogFormatJSON,
+			entry:  entry,
+			want:   `{"msg":"access","time":"2024-03-05T14:07:09.000+0530","remote_addr":"192.0.2.10","user":"user-1","method":"GET","uri":"/api/v1/apps?limit=1","protocol":"HTTP/1.1","status":200,"size":512,"referer":"https://example.com/","user_agent":"curl/8.0 \"quoted\"","duration":"1.5ms","request_id":"request-1"}` + "\n",
+		},
+		{
+			// Test case 4: Missing user and empty bodies are dashes
+			name:   "common anonymous",
+			format: config.AccessLogFormatCommon,
+			entry:  AccessEntry{RemoteAddr: "192.0.2.10", Time: entry.Time, Method: "HEAD", URI: "/livez", Protocol: "HTTP/1.1", Status: 204},
+			want:   `192.0.2.10 - - [05/Mar/2024:14:07:09 +0530] "HEAD /livez HTTP/1.1" 204 -` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "access.log")
+			accessLog, err := NewAccessLogger(config.LoggingConfig{AccessLogFormat: tt.format, AccessLogPath: path, FileMaxSize: 1})
+			assert.Nil(t, err)
+
+			accessLog.Log(tt.entry)
+			assert.Nil(t, accessLog.Sync())
+
+			written, err := os.ReadFile(path)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, jsonTimestamp.ReplaceAllString(string(written), ""))
+		})
+	}
+
+	// Test case 5: No access logger when the access log is disabled
+	t.Run("disabled", func(t *testing.T) {
+		accessLog, err := NewAccessLogger(config.LoggingConfig{})
+		assert.Nil(t, err)
+		assert.Nil(t, accessLog)
+	})
+
+	// Test case 6: The common line is the prefix of the combined line
+	t.Run("common log line", func(t *testing.T) {
+		assert.Equal(t, `192.0.2.10 - user-1 [05/Mar/2024:14:07:09 +0530] "GET /api/v1/apps?limit=1 HTTP/1.1" 200 512`, commonLogLine(entry))
+	})
+}
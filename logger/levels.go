@@ -0,0 +1,206 @@
+package logger
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LevelsState describes the current log levels
+type LevelsState struct {
+	// Level of the root logger and of named loggers without an override
+	Level string `json:"level"`
+	// Named maps logger names to their level override
+	Named map[string]string `json:"named"`
+	// RevertAt maps logger names, "" for the root logger, to the time their
+	// level goes back to its default
+	RevertAt map[string]time.Time `json:"revert_at"`
+}
+
+// Levels controls the level of the root logger and of named loggers at
+// runtime. A named level applies to the logger with that name and to its
+// children, e.g. "http" also covers "http.access".
+type Levels struct {
+	root         zap.AtomicLevel
+	defaultLevel zapcore.Level
+	named        atomic.Pointer[map[string]zapcore.Level]
+
+	mu       sync.Mutex
+	timers   map[string]*time.Timer
+	revertAt map[string]time.Time
+}
+
+func newLevels(root zap.AtomicLevel) *Levels {
+	levels := &Levels{
+		root:         root,
+		defaultLevel: root.Level(),
+		timers:       map[string]*time.Timer{},
+		revertAt:     map[string]time.Time{},
+	}
+	levels.named.Store(&map[string]zapcore.Level{})
+	return levels
+}
+
+// SetLevel changes the level of the named logger, or of the root logger when
+// name is empty. A positive revertAfter restores the default level once it
+// has elapsed.
+func (l *Levels) SetLevel(name string, level zapcore.Level, revertAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if name == "" {
+		l.root.SetLevel(level)
+	} else {
+		named := l.copyNamed()
+		named[name] = level
+		l.named.Store(&named)
+	}
+
+	l.stopTimer(name)
+	if revertAfter > 0 {
+		l.revertAt[name] = time.Now().Add(revertAfter)
+		var timer *time.Timer
+		timer = time.AfterFunc(revertAfter, func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			// The level may have been changed again while waiting for the lock
+			if l.timers[name] == timer {
+				l.revert(name)
+			}
+		})
+		l.timers[name] = timer
+	}
+}
+
+// Revert restores the default level of the named logger, or of the root
+// logger when name is empty
+func (l *Levels) Revert(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.revert(name)
+}
+
+// Reset restores the default level of every logger
+func (l *Levels) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.revert("")
+	for name := range *l.named.Load() {
+		l.revert(name)
+	}
+}
+
+// State returns the current levels
+func (l *Levels) State() LevelsState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	state := LevelsState{
+		Level:    l.root.Level().String(),
+		Named:    map[string]string{},
+		RevertAt: map[string]time.Time{},
+	}
+	for name, level := range *l.named.Load() {
+		state.Named[name] = level.String()
+	}
+	for name, at := range l.revertAt {
+		state.RevertAt[name] = at
+	}
+	return state
+}
+
+// Enabled reports whether the named logger writes entries at level
+func (l *Levels) Enabled(name string, level zapcore.Level) bool {
+	named := *l.named.Load()
+	if len(named) > 0 {
+		// The most specific override wins
+		for n := name; n != ""; {
+			if override, ok := named[n]; ok {
+				return override.Enabled(level)
+			}
+			dot := strings.LastIndexByte(n, '.')
+			if dot < 0 {
+				break
+			}
+			n = n[:dot]
+		}
+	}
+	return l.root.Enabled(level)
+}
+
+// revert must be called with mu held
+func (l *Levels) revert(name string) {
+	l.stopTimer(name)
+	if name == "" {
+		l.root.SetLevel(l.defaultLevel)
+		return
+	}
+	named := l.copyNamed()
+	delete(named, name)
+	l.named.Store(&named)
+}
+
+// stopTimer must be called with mu held
+func (l *Levels) stopTimer(name string) {
+	if timer, ok := l.timers[name]; ok {
+		timer.Stop()
+		delete(l.timers, name)
+	}
+	delete(l.revertAt, name)
+}
+
+func (l *Levels) copyNamed() map[string]zapcore.Level {
+	named := map[string]zapcore.Level{}
+	for name, level := range *l.named.Load() {
+		named[name] = level
+	}
+	return named
+}
+
+// minLevel is the lowest level any logger currently writes
+func (l *Levels) minLevel() zapcore.Level {
+	level := l.root.Level()
+	for _, override := range *l.named.Load() {
+		if override < level {
+			level = override
+		}
+	}
+	return level
+}
+
+// wrapCore filters the entries of core by the level of their logger
+func (l *Levels) wrapCore(core zapcore.Core) zapcore.Core {
+	return &levelCore{Core: core, levels: l}
+}
+
+// levelCore is a zapcore.Core whose level depends on the logger name. The
+// wrapped core must enable every level.
+type levelCore struct {
+	zapcore.Core
+	levels *Levels
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.levels.minLevel().Enabled(level)
+}
+
+// Level lets zapcore.LevelOf report the lowest enabled level
+func (c *levelCore) Level() zapcore.Level {
+	return c.levels.minLevel()
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+func (c *levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.Enabled(entry.LoggerName, entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
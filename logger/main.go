@@ -1,9 +1,12 @@
 package logger
 
 import (
-	log "github.com/sirupsen/logrus"
+	"os"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var defaultEncoderConfig = zapcore.EncoderConfig{
@@ -20,42 +23,63 @@ var defaultEncoderConfig = zapcore.EncoderConfig{
 	EncodeCaller:   zapcore.ShortCallerEncoder,
 }
 
-var zapServerConfig = zap.Config{
-	Level:            zap.NewAtomicLevelAt(zap.InfoLevel),
-	Development:      false,
-	Encoding:         "json",
-	EncoderConfig:    defaultEncoderConfig,
-	OutputPaths:      []string{"stdout"},
-	ErrorOutputPaths: []string{"stderr"},
+// levels of the last root logger built
+var levels *Levels
+
+// RuntimeLevels returns the levels of the root logger built by NewRootLogger,
+// which can be changed while the process runs
+func RuntimeLevels() *Levels {
+	return levels
 }
 
 // NewRootLogger instantiates zap.Logger with given configuration
-func NewRootLogger(debug, developement bool) (*zap.Logger, error) {
-	var err error
-	var logger *zap.Logger
-
+// Entries are written to stdout and, when cfg.FilePath is set, to a rotating
+// log file. Development mode writes colored console output to stdout while
+// log files always get JSON.
+func NewRootLogger(debug, developement bool, cfg config.LoggingConfig) (*zap.Logger, error) {
+	level := zap.NewAtomicLevelAt(zap.InfoLevel)
 	if debug {
 		// enable debug level
-		zapServerConfig.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
-		if !developement {
-			return zapServerConfig.Build(zap.AddStacktrace(zap.ErrorLevel), zap.AddCaller())
-		}
-		zapServerConfig.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
-		return zapServerConfig.Build(zap.AddStacktrace(zap.ErrorLevel), zap.AddCaller())
+		level = zap.NewAtomicLevelAt(zap.DebugLevel)
 	}
 
-	if developement {
-		zapServerConfig.Encoding = "console"
-		zapServerConfig.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
-		return zapServerConfig.Build(zap.AddStacktrace(zap.ErrorLevel), zap.AddCaller())
+	// Cores enable every level and entries are filtered by RuntimeLevels
+	// instead, so the level of the root logger and of each named logger can
+	// be changed at runtime
+	levels = newLevels(level)
+
+	var cores []zapcore.Core
+	if cfg.Stdout || cfg.FilePath == "" {
+		encoder := zapcore.NewJSONEncoder(defaultEncoderConfig)
+		if developement {
+			encoderConfig := defaultEncoderConfig
+			encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+			encoder = zapcore.NewConsoleEncoder(encoderConfig)
+		}
+		cores = append(cores, zapcore.NewCore(encoder, zapcore.Lock(os.Stdout), zapcore.DebugLevel))
+	}
+	if cfg.FilePath != "" {
+		file := zapcore.AddSync(newRotatingFile(cfg.FilePath, cfg))
+		cores = append(cores, zapcore.NewCore(zapcore.NewJSONEncoder(defaultEncoderConfig), file, zapcore.DebugLevel))
 	}
 
-	log.SetFormatter(&log.JSONFormatter{})
-	logger, err = zapServerConfig.Build(zap.AddStacktrace(zap.ErrorLevel), zap.AddCaller())
+	return zap.New(
+		levels.wrapCore(zapcore.NewTee(cores...)),
+		zap.ErrorOutput(zapcore.Lock(os.Stderr)),
+		zap.AddStacktrace(zap.ErrorLevel),
+		zap.AddCaller(),
+	), nil
+}
 
-	if err != nil {
-		panic(err)
+// newRotatingFile returns a writer to path that is rotated and compressed
+// according to the file settings of cfg
+func newRotatingFile(path string, cfg config.LoggingConfig) *lumberjack.Logger {
+	return &lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    cfg.FileMaxSize,
+		MaxAge:     cfg.FileMaxAge,
+		MaxBackups: cfg.FileMaxBackups,
+		Compress:   cfg.FileCompress,
+		LocalTime:  true,
 	}
-
-	return logger, err
 }
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package logger
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// HandleSignals does nothing on platforms without SIGUSR1 and SIGHUP
+func (l *Levels) HandleSignals(ctx context.Context, revertAfter time.Duration, logger *zap.Logger) {}
@@ -0,0 +1,37 @@
+//go:build unix
+
+package logger
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// HandleSignals changes the levels on signals until ctx is done. SIGUSR1
+// switches the root logger to debug for revertAfter and SIGHUP restores the
+// default level of every logger.
+func (l *Levels) HandleSignals(ctx context.Context, revertAfter time.Duration, logger *zap.Logger) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-signals:
+			if sig == syscall.SIGUSR1 {
+				l.SetLevel("", zapcore.DebugLevel, revertAfter)
+			} else {
+				l.Reset()
+			}
+			logger.Info("log levels changed by signal", zap.String("signal", sig.String()), zap.Any("levels", l.State()))
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/models"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// AdminUserID identifies requests authenticated with the admin token
+const AdminUserID = "admin"
+
+// AdminAuth only lets requests carrying the admin token as a bearer token, or
+// the credentials of a caller with the admin scope, through. Admin endpoints
+// are disabled while no token is configured.
+func (m Middleware) AdminAuth(c *fiber.Ctx) error {
+	if m.config.Admin.Token == "" {
+		return utils.JSONFail(c, http.StatusForbidden, constants.ErrorAdminDisabled)
+	}
+
+	token, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	if ok && subtle.ConstantTimeCompare([]byte(token), []byte(m.config.Admin.Token)) == 1 {
+		c.Locals(constants.LocalUserID, AdminUserID)
+		return c.Next()
+	}
+
+	caller, err := m.authenticate(c)
+	if err != nil && !errors.Is(err, errUnauthenticated) {
+		utils.Logger(c, m.logger).Error("error while authenticating request", zap.Error(err))
+		return utils.JSONError(c, http.StatusInternalServerError, constants.ErrorInternalServer)
+	}
+	if err == nil && caller.hasScope(models.ScopeAdmin) {
+		c.Locals(constants.LocalUserID, caller.subject)
+		return c.Next()
+	}
+
+	utils.Logger(c, m.logger).Warn("rejected admin request", zap.String("path", c.Path()))
+	return utils.JSONFail(c, http.StatusUnauthorized, constants.ErrorUnauthorized)
+}
@@ -0,0 +1,51 @@
+package middlewares
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/models"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/rbac"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// HeaderAPIKey is the header API keys may be sent in instead of a bearer token
+const HeaderAPIKey = "X-API-Key"
+
+// APIKeyStore looks up the active API key matching a key sent by a client
+type APIKeyStore interface {
+	GetAPIKeyByKey(ctx context.Context, key string) (models.APIKey, error)
+}
+
+// apiKeyPrincipal authenticates the caller by the API key sent as key
+func (m Middleware) apiKeyPrincipal(c *fiber.Ctx, key string) (principal, error) {
+	apiKey, err := m.credentials.APIKeys.GetAPIKeyByKey(c.UserContext(), key)
+	if errors.Is(err, sql.ErrNoRows) {
+		utils.Logger(c, m.logger).Warn("rejected unknown or revoked api key", zap.String("path", c.Path()))
+		return principal{}, errUnauthenticated
+	}
+	if err != nil {
+		return principal{}, fmt.Errorf("looking up api key: %w", err)
+	}
+	return principal{subject: fmt.Sprintf("api-key:%d", apiKey.ID), roles: scopeRoles(apiKey.Scopes), scopes: apiKey.Scopes}, nil
+}
+
+// scopeRoles returns the role an API key acts as under the rbac policy: keys
+// with the admin scope are admins, keys allowed to write are editors and
+// read only keys are viewers
+func scopeRoles(scopes []string) []string {
+	switch {
+	case slices.Contains(scopes, models.ScopeAdmin):
+		return []string{rbac.RoleAdmin}
+	case slices.Contains(scopes, models.ScopeAppsWrite) || slices.Contains(scopes, models.ScopeReviewsWrite):
+		return []string{rbac.RoleEditor}
+	case slices.Contains(scopes, models.ScopeAppsRead):
+		return []string{rbac.RoleViewer}
+	}
+	return nil
+}
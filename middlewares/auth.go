@@ -0,0 +1,137 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/models"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/jwks"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/kratos"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/rbac"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// errUnauthenticated is returned when a request carries no valid credentials
+var errUnauthenticated = errors.New("unauthenticated")
+
+// RoleScopes lists the scopes each role grants, unknown roles grant nothing
+var RoleScopes = map[string][]string{
+	rbac.RoleViewer: {models.ScopeAppsRead},
+	rbac.RoleEditor: {models.ScopeAppsRead, models.ScopeAppsWrite, models.ScopeReviewsWrite},
+	rbac.RoleAdmin:  {models.ScopeAdmin},
+}
+
+// Credentials are the sources callers are authenticated against, a nil
+// identity source, JWT verifier or session client disables that kind of
+// credentials
+type Credentials struct {
+	APIKeys    APIKeyStore
+	Identities rbac.IdentitySource
+	JWT        *jwks.Verifier
+	Sessions   *kratos.Client
+}
+
+// principal is the authenticated caller of a request
+type principal struct {
+	subject string
+	roles   []string
+	scopes  []string
+}
+
+// identityPrincipal returns the caller of identity, with the scopes of its roles
+func identityPrincipal(subject string, roles []string) principal {
+	return principal{subject: subject, roles: roles, scopes: roleScopes(roles)}
+}
+
+// hasScope reports whether the caller was granted scope
+func (p principal) hasScope(scope string) bool {
+	return slices.Contains(p.scopes, scope) || slices.Contains(p.scopes, models.ScopeAdmin)
+}
+
+// Authorize only lets requests through whose caller was authenticated by one
+// of the credentials, is within its rate limit, was granted every scope and
+// holds a role the policy allows for the route and method.
+func (m Middleware) Authorize(scopes ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		caller, err := m.authenticate(c)
+		if errors.Is(err, errUnauthenticated) {
+			// Callers without valid credentials share the bucket of their address
+			if m.rateLimited(c, "ip:"+c.IP()) {
+				return utils.JSONFail(c, http.StatusTooManyRequests, constants.ErrorRateLimited)
+			}
+			return utils.JSONFail(c, http.StatusUnauthorized, constants.ErrorUnauthorized)
+		}
+		if err != nil {
+			utils.Logger(c, m.logger).Error("error while authenticating request", zap.Error(err))
+			return utils.JSONError(c, http.StatusInternalServerError, constants.ErrorInternalServer)
+		}
+
+		c.Locals(constants.LocalUserID, caller.subject)
+		if m.rateLimited(c, caller.subject) {
+			return utils.JSONFail(c, http.StatusTooManyRequests, constants.ErrorRateLimited)
+		}
+		for _, scope := range scopes {
+			if !caller.hasScope(scope) {
+				utils.Logger(c, m.logger).Warn("rejected caller without required scope",
+					zap.String("user_id", caller.subject), zap.String("scope", scope), zap.String("path", c.Path()))
+				return utils.JSONFail(c, http.StatusForbidden, constants.ErrorInsufficientScope)
+			}
+		}
+		if m.policy != nil && !m.policy.Policy().Allowed(c.Method(), c.Route().Path, caller.roles) {
+			utils.Logger(c, m.logger).Warn("rejected caller without allowed role",
+				zap.String("user_id", caller.subject), zap.Strings("roles", caller.roles), zap.String("method", c.Method()), zap.String("route", c.Route().Path))
+			return utils.JSONFail(c, http.StatusForbidden, constants.ErrorRoleNotAllowed)
+		}
+		return c.Next()
+	}
+}
+
+// authenticate resolves the caller from the credentials of the request, in
+// order: a bearer JWT, a bearer Kratos session token, a bearer token known to
+// the identity source, a bearer or X-API-Key API key, and a Kratos session
+// token or cookie.
+func (m Middleware) authenticate(c *fiber.Ctx) (principal, error) {
+	sessions := m.credentials.Sessions
+	token, bearer := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	bearer = bearer && token != ""
+
+	switch {
+	case bearer && m.credentials.JWT != nil && strings.Count(token, ".") == 2:
+		return m.jwtPrincipal(c, token)
+	case bearer && sessions != nil && strings.HasPrefix(token, kratos.SessionTokenPrefix):
+		return m.sessionPrincipal(c, kratos.Credentials{Token: token})
+	case bearer && m.credentials.Identities != nil:
+		identity, err := m.credentials.Identities.Identify(c.UserContext(), token)
+		if errors.Is(err, rbac.ErrUnknownToken) {
+			return m.apiKeyPrincipal(c, token)
+		}
+		if err != nil {
+			return principal{}, err
+		}
+		return identityPrincipal(identity.Subject, identity.Roles), nil
+	case bearer:
+		return m.apiKeyPrincipal(c, token)
+	case c.Get(HeaderAPIKey) != "":
+		return m.apiKeyPrincipal(c, c.Get(HeaderAPIKey))
+	case sessions != nil && (c.Get(kratos.HeaderSessionToken) != "" || c.Cookies(sessions.SessionCookie()) != ""):
+		return m.sessionPrincipal(c, kratos.Credentials{
+			Token:  c.Get(kratos.HeaderSessionToken),
+			Cookie: c.Cookies(sessions.SessionCookie()),
+		})
+	}
+	return principal{}, errUnauthenticated
+}
+
+// roleScopes returns the scopes granted by roles
+func roleScopes(roles []string) []string {
+	var scopes []string
+	for _, role := range roles {
+		scopes = append(scopes, RoleScopes[role]...)
+	}
+	return scopes
+}
@@ -1,54 +1,86 @@
 package middlewares
 
 import (
+	"math/rand/v2"
 	"strings"
+	"time"
 
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	appLogger "git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/logger"
 	pMetrics "git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/prometheus"
+	appUtils "git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
 	"github.com/gofiber/fiber/v2"
-	"github.com/samber/lo"
+	"github.com/gofiber/fiber/v2/utils"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-var (
-	zapCoreField []zapcore.Field
-	// Add path that needs to excluded from logging
-	ignorePathList = []string{
-		"/docs",
-		"/assets/redoc.css",
-		"/assets/redoc.standalone.js",
-		"/assets/swagger.json",
-		"/favicon.ico",
-	}
-)
+// AccessLoggerName is the name of the logger writing request logs
+const AccessLoggerName = "http"
 
 // Handler will log each request
-func LogHandler(logger *zap.Logger, pMetrics *pMetrics.PrometheusMetrics) fiber.Handler {
+// Sensitive headers and JSON fields are redacted, bodies are truncated and
+// successful requests are sampled according to cfg
+// When accessLog is not nil every request that is not ignored is also written
+// to the access log, regardless of sampling and log levels
+func LogHandler(logger *zap.Logger, accessLog *appLogger.AccessLogger, metrics *pMetrics.PrometheusMetrics, cfg config.LoggingConfig) fiber.Handler {
+	filter := newLogFilter(cfg)
+
 	return func(ctx *fiber.Ctx) error {
+		start := time.Now()
+		metrics.RequestsInFlight.Inc()
+		defer metrics.RequestsInFlight.Dec()
+
+		// Let the error handler write the response of a failed handler so the
+		// request is still logged and counted with its final status code
 		err := ctx.Next()
 		if err != nil {
-			return err
+			if err := ctx.App().ErrorHandler(ctx, err); err != nil {
+				_ = ctx.SendStatus(fiber.StatusInternalServerError)
+			}
 		}
 
-		exits := lo.Contains(ignorePathList, ctx.Path()) || strings.HasPrefix(string(ctx.Response().Header.ContentType()), "image/") || strings.HasPrefix(string(ctx.Response().Header.ContentType()), "text/")
-		if !exits {
-			zapCoreField = []zapcore.Field{
+		status := ctx.Response().Header.StatusCode()
+		successful := status >= 100 && status <= 399
+		exits := filter.ignored(ctx.Path()) || strings.HasPrefix(string(ctx.Response().Header.ContentType()), "image/") || strings.HasPrefix(string(ctx.Response().Header.ContentType()), "text/")
+		sampled := !successful || cfg.SuccessSampleRate >= 1 || rand.Float64() < cfg.SuccessSampleRate
+		if accessLog != nil && !filter.ignored(ctx.Path()) {
+			accessLog.Log(appLogger.AccessEntry{
+				RemoteAddr: ctx.IP(),
+				User:       appUtils.UserID(ctx),
+				Time:       start,
+				Method:     string(ctx.Request().Header.Method()),
+				URI:        string(ctx.Request().RequestURI()),
+				Protocol:   string(ctx.Request().Header.Protocol()),
+				Status:     status,
+				Size:       len(ctx.Response().Body()),
+				Referer:    string(ctx.Request().Header.Referer()),
+				UserAgent:  string(ctx.Request().Header.UserAgent()),
+				Duration:   time.Since(start),
+				RequestID:  appUtils.RequestID(ctx),
+			})
+		}
+
+		if !exits && sampled {
+			zapCoreField := []zapcore.Field{
 				zap.String("host", ctx.Hostname()),
 				zap.String("method", string(ctx.Request().Header.Method())),
 				zap.String("uri", ctx.BaseURL()),
 				zap.String("protocol", ctx.Protocol()),
 				zap.String("username", string(ctx.Request().URI().Username())),
-				zap.String("requestHeaders", string(ctx.Request().Header.Header())),
-				zap.String("responseHeaders", string(ctx.Response().Header.Header())),
-				zap.String("request", string(ctx.Request().Body())),
-				zap.String("response", ctx.Response().String()),
-				zap.Int("status", ctx.Response().Header.StatusCode()),
+				zap.String("requestHeaders", filter.requestHeaders(&ctx.Request().Header)),
+				zap.String("responseHeaders", filter.responseHeaders(&ctx.Response().Header)),
+				zap.String("request", filter.body(ctx.Request().Body(), string(ctx.Request().Header.ContentType()))),
+				zap.String("response", filter.body(ctx.Response().Body(), string(ctx.Response().Header.ContentType()))),
+				zap.Int("status", status),
 				zap.Int("size", ctx.Response().Header.ContentLength()),
 			}
-			if ctx.Response().Header.StatusCode() >= 100 && ctx.Response().Header.StatusCode() <= 399 {
-				logger.Debug("Handled successful request", zapCoreField...)
+			// Named so access logs can be switched to debug on their own
+			requestLogger := appUtils.Logger(ctx, logger).Named(AccessLoggerName)
+			if successful {
+				requestLogger.Debug("Handled successful request", zapCoreField...)
 			} else {
-				logger.Error("handled error request", zapCoreField...)
+				requestLogger.Error("handled error request", zapCoreField...)
 			}
 		}
 
@@ -56,14 +88,23 @@ func LogHandler(logger *zap.Logger, pMetrics *pMetrics.PrometheusMetrics) fiber.
 		// Because /metrics endpoint response is send first and
 		// Respected status code counter increase next
 		if ctx.Response().StatusCode() >= 200 && ctx.Response().StatusCode() < 300 {
-			pMetrics.RequestsMetrics.WithLabelValues("2xx").Inc()
+			metrics.RequestsMetrics.WithLabelValues("2xx").Inc()
 		} else if ctx.Response().StatusCode() >= 300 && ctx.Response().StatusCode() < 400 {
-			pMetrics.RequestsMetrics.WithLabelValues("3xx").Inc()
+			metrics.RequestsMetrics.WithLabelValues("3xx").Inc()
 		} else if ctx.Response().StatusCode() >= 400 && ctx.Response().StatusCode() < 500 {
-			pMetrics.RequestsMetrics.WithLabelValues("4xx").Inc()
+			metrics.RequestsMetrics.WithLabelValues("4xx").Inc()
 		} else if ctx.Response().StatusCode() >= 500 {
-			pMetrics.RequestsMetrics.WithLabelValues("5xx").Inc()
+			metrics.RequestsMetrics.WithLabelValues("5xx").Inc()
+		}
+
+		// Label by route template
+		route, ok := matchedRoute(ctx, err)
+		if !ok {
+			route = pMetrics.UnmatchedRoute
 		}
+		method := utils.CopyString(ctx.Method())
+		metrics.RequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+		metrics.ResponseSize.WithLabelValues(method, route).Observe(float64(len(ctx.Response().Body())))
 		return nil
 	}
 }
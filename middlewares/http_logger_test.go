@@ -0,0 +1,108 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	appLogger "git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/logger"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/middlewares"
+	pMetrics "git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/prometheus"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// TestLogHandlerRouteLabel tests labelling request metrics by route template
+func TestLogHandlerRouteLabel(t *testing.T) {
+	metrics := pMetrics.InitPrometheusMetrics(config.MetricsConfig{})
+
+	app := fiber.New()
+	app.Use(middlewares.LogHandler(zap.NewNop(), nil, metrics, config.LoggingConfig{}))
+	// Middlewares registered after LogHandler become the current route too
+	app.Use(func(c *fiber.Ctx) error {
+		return c.Next()
+	})
+	app.Get("/route-label/:id", func(c *fiber.Ctx) error {
+		return c.SendString(c.Params("id"))
+	})
+	app.Get("/route-label-missing/:id", func(c *fiber.Ctx) error {
+		return fiber.ErrNotFound
+	})
+
+	scrape := func() string {
+		recorder := httptest.NewRecorder()
+		promhttp.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		return recorder.Body.String()
+	}
+
+	// Test case 1: matched requests are labelled with the route template
+	t.Run("matched route", func(t *testing.T) {
+		res, err := app.Test(httptest.NewRequest(http.MethodGet, "/route-label/1", nil))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Contains(t, scrape(), `golang_api_request_duration_seconds_count{method="GET",route="/route-label/:id"} 1`)
+	})
+
+	// Test case 2: unknown paths are labelled unmatched, not with the path of
+	// a later middleware
+	t.Run("unknown path", func(t *testing.T) {
+		res, err := app.Test(httptest.NewRequest(http.MethodGet, "/route-label-unknown", nil))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		body := scrape()
+		assert.Contains(t, body, `golang_api_request_duration_seconds_count{method="GET",route="unmatched"} 1`)
+		assert.NotContains(t, body, `route="/"`)
+	})
+
+	// Test case 3: requests with a method no route matches are unmatched too
+	t.Run("unknown method", func(t *testing.T) {
+		res, err := app.Test(httptest.NewRequest(http.MethodPost, "/route-label/1", nil))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+		assert.Contains(t, scrape(), `golang_api_request_duration_seconds_count{method="POST",route="unmatched"} 1`)
+	})
+
+	// Test case 4: a matched route answering 404 keeps its route template
+	t.Run("matched route not found", func(t *testing.T) {
+		res, err := app.Test(httptest.NewRequest(http.MethodGet, "/route-label-missing/1", nil))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		assert.Contains(t, scrape(), `golang_api_request_duration_seconds_count{method="GET",route="/route-label-missing/:id"} 1`)
+	})
+}
+
+// TestLogHandlerAccessLog tests the access log entries of requests
+func TestLogHandlerAccessLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	accessLog, err := appLogger.NewAccessLogger(config.LoggingConfig{AccessLogFormat: config.AccessLogFormatCommon, AccessLogPath: path, FileMaxSize: 1})
+	assert.Nil(t, err)
+
+	app := fiber.New()
+	app.Use(middlewares.LogHandler(zap.NewNop(), accessLog, pMetrics.InitPrometheusMetrics(config.MetricsConfig{}), config.LoggingConfig{}))
+	app.Get("/access-log", func(c *fiber.Ctx) error {
+		// Set by the authentication middlewares
+		c.Locals(constants.LocalUserID, "user-1")
+		return c.SendStatus(http.StatusNoContent)
+	})
+
+	// Test case 1: The user is the authenticated caller, not the basic auth
+	// username of the URI
+	t.Run("authenticated user", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "http://someone@example.com/access-log", nil)
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+		assert.Nil(t, accessLog.Sync())
+
+		written, err := os.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Contains(t, string(written), ` - user-1 [`)
+		assert.Contains(t, string(written), `"GET /access-log HTTP/1.1" 204 `)
+	})
+}
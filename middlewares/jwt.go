@@ -0,0 +1,19 @@
+package middlewares
+
+import (
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// jwtPrincipal authenticates the caller by a JWT, the subject of the token
+// becomes the user ID recorded for the request
+func (m Middleware) jwtPrincipal(c *fiber.Ctx, token string) (principal, error) {
+	identity, err := m.credentials.JWT.Verify(c.UserContext(), token)
+	if err != nil {
+		utils.Logger(c, m.logger).Warn("rejected invalid jwt", zap.String("path", c.Path()), zap.Error(err))
+		return principal{}, errUnauthenticated
+	}
+
+	return identityPrincipal(identity.Subject, identity.Roles), nil
+}
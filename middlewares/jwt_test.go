@@ -0,0 +1,107 @@
+package middlewares_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/middlewares"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/models"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/jwks"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/rbac"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// noAPIKeys is an APIKeyStore without any key
+type noAPIKeys struct{}
+
+func (noAPIKeys) GetAPIKeyByKey(ctx context.Context, key string) (models.APIKey, error) {
+	return models.APIKey{}, sql.ErrNoRows
+}
+
+// TestJWTAuth tests authenticating requests with JWTs
+func TestJWTAuth(t *testing.T) {
+	secret := []byte("a-locally-generated-hmac-secret!")
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	document := fmt.Sprintf(`{"keys":[{"kty":"oct","kid":"hmac","k":%q}]}`, base64.RawURLEncoding.EncodeToString(secret))
+	assert.Nil(t, os.WriteFile(path, []byte(document), 0o600))
+
+	verifier := jwks.New(config.JWTConfig{
+		JWKSFile:            path,
+		JWKSRefreshInterval: time.Minute,
+		Algorithms:          []string{"HS256"},
+		RolesClaim:          "roles",
+	})
+	middleware := middlewares.NewMiddleware(config.AppConfig{}, middlewares.Credentials{APIKeys: noAPIKeys{}, JWT: verifier}, nil, nil, zap.NewNop())
+
+	app := fiber.New()
+	app.Get("/apps", middleware.Authorize(models.ScopeAppsRead), func(c *fiber.Ctx) error {
+		return c.SendString(utils.UserID(c))
+	})
+	app.Post("/apps", middleware.Authorize(models.ScopeAppsWrite), func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusCreated)
+	})
+
+	viewerToken := func(t *testing.T) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"sub":   "user-1",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"roles": []string{rbac.RoleViewer},
+		})
+		token.Header["kid"] = "hmac"
+		signed, err := token.SignedString(secret)
+		assert.Nil(t, err)
+		return signed
+	}
+
+	// Test case 1: Viewer can read and the subject is recorded
+	t.Run("viewer reads", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/apps", nil)
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+viewerToken(t))
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		body, err := io.ReadAll(res.Body)
+		assert.Nil(t, err)
+		assert.Equal(t, "user-1", string(body))
+	})
+
+	// Test case 2: Viewer can't write
+	t.Run("viewer writes", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/apps", nil)
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+viewerToken(t))
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+
+	// Test case 3: Tampered token
+	t.Run("invalid token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/apps", nil)
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+viewerToken(t)+"x")
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+
+	// Test case 4: No credentials
+	t.Run("missing token", func(t *testing.T) {
+		res, err := app.Test(httptest.NewRequest(http.MethodGet, "/apps", nil))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+}
@@ -1,18 +1,76 @@
 package middlewares
 
 import (
+	"errors"
+	"html"
+
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/ratelimit"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/rbac"
+	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
 
 type Middleware struct {
-	config config.AppConfig
-	logger *zap.Logger
+	config      config.AppConfig
+	credentials Credentials
+	policy      *rbac.PolicyFile
+	limiter     *ratelimit.Limiter
+	logger      *zap.Logger
 }
 
-func NewMiddleware(cfg config.AppConfig, logger *zap.Logger) Middleware {
+// NewMiddleware returns the middlewares sharing cfg, the credentials callers
+// are authenticated against, the rbac policy they are authorized by and the
+// limiter throttling them. Only scopes are checked when policy is nil, and
+// requests aren't throttled when limiter is nil.
+func NewMiddleware(cfg config.AppConfig, credentials Credentials, policy *rbac.PolicyFile, limiter *ratelimit.Limiter, logger *zap.Logger) Middleware {
 	return Middleware{
-		config: cfg,
-		logger: logger,
+		config:      cfg,
+		credentials: credentials,
+		policy:      policy,
+		limiter:     limiter,
+		logger:      logger,
+	}
+}
+
+// responseStatus returns the status code a request ends with. When a handler
+// failed with err, the error handler has not written the response yet, so the
+// status is derived from err the way fiber's default error handler does.
+func responseStatus(c *fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+	return fiber.StatusInternalServerError
+}
+
+// localRouteNotFound is the ctx.Locals key marking requests no route matched
+const localRouteNotFound = "routeNotFound"
+
+// matchedRoute returns the template of the route that handled a request once
+// the handler chain has run with err, and false when no route matched.
+// c.Route() alone can't tell: every app.Use middleware that runs becomes the
+// current route, so an unmatched request still has one. Instead the error
+// fiber's router ends the chain with is recognised, and remembered for the
+// middlewares further up the chain once the error has been handled.
+func matchedRoute(c *fiber.Ctx, err error) (string, bool) {
+	if notFound, _ := c.Locals(localRouteNotFound).(bool); notFound || routeNotFound(c, err) {
+		c.Locals(localRouteNotFound, true)
+		return "", false
+	}
+	return c.Route().Path, true
+}
+
+// routeNotFound reports whether err is the error fiber's router returns when
+// no route matches the path, or none matches the method of the request
+func routeNotFound(c *fiber.Ctx, err error) bool {
+	if err == fiber.ErrMethodNotAllowed {
+		return true
 	}
+	var fiberErr *fiber.Error
+	return errors.As(err, &fiberErr) && fiberErr.Code == fiber.StatusNotFound &&
+		fiberErr.Message == "Cannot "+c.Method()+" "+html.EscapeString(string(c.Request().URI().PathOriginal()))
 }
@@ -0,0 +1,65 @@
+package middlewares
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Rate limit headers, as in the IETF RateLimit header fields draft
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRateLimitPolicy    = "RateLimit-Policy"
+)
+
+// rateLimited takes a token for the request from the bucket of client, sets
+// the rate limit headers and reports whether the client ran out of tokens.
+// Requests are let through when the store fails, so an unavailable store
+// doesn't take the API down with it.
+func (m Middleware) rateLimited(c *fiber.Ctx, client string) bool {
+	if m.limiter == nil {
+		return false
+	}
+
+	write := !isRead(c.Method())
+	result, limited, err := m.limiter.Take(c.UserContext(), client, write)
+	if err != nil {
+		utils.Logger(c, m.logger).Error("error while rate limiting request", zap.Error(err))
+		return false
+	}
+	if !limited {
+		return false
+	}
+
+	limit := m.limiter.Limit(write)
+	c.Set(HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+	c.Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+	c.Set(HeaderRateLimitReset, ceilSeconds(result.ResetAfter))
+	c.Set(HeaderRateLimitPolicy, fmt.Sprintf("%d;w=%s", limit.Burst, ceilSeconds(limit.Period)))
+	if result.Allowed {
+		return false
+	}
+
+	c.Set(fiber.HeaderRetryAfter, ceilSeconds(result.RetryAfter))
+	utils.Logger(c, m.logger).Warn("rejected rate limited request",
+		zap.String("client", client), zap.Bool("write", write), zap.String("path", c.Path()))
+	return true
+}
+
+// isRead reports whether method only reads, reads and writes are limited
+// separately
+func isRead(method string) bool {
+	return method == fiber.MethodGet || method == fiber.MethodHead || method == fiber.MethodOptions
+}
+
+// ceilSeconds formats d as whole seconds, rounded up so clients never retry early
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,112 @@
+package middlewares_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/middlewares"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/models"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/ratelimit"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/rbac"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// failingStore is a rate limit store that is unavailable
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store unavailable")
+}
+
+func rateLimitedApp(store ratelimit.Store) *fiber.App {
+	credentials := middlewares.Credentials{
+		APIKeys:    noAPIKeys{},
+		Identities: rbac.StaticTokens{"first-token": rbac.RoleEditor, "second-token": rbac.RoleEditor},
+	}
+	limiter := ratelimit.New(config.RateLimitConfig{Read: 2, Write: 1, Period: time.Minute}, store)
+	middleware := middlewares.NewMiddleware(config.AppConfig{}, credentials, nil, limiter, zap.NewNop())
+
+	app := fiber.New()
+	app.Get("/apps", middleware.Authorize(models.ScopeAppsRead), func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+	app.Post("/apps", middleware.Authorize(models.ScopeAppsWrite), func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusCreated)
+	})
+	return app
+}
+
+func send(t *testing.T, app *fiber.App, method, token string) *http.Response {
+	req := httptest.NewRequest(method, "/apps", nil)
+	if token != "" {
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+	}
+	res, err := app.Test(req)
+	assert.Nil(t, err)
+	return res
+}
+
+// TestRateLimit tests throttling clients with token buckets
+func TestRateLimit(t *testing.T) {
+	app := rateLimitedApp(ratelimit.NewMemoryStore())
+
+	// Test case 1: Allowed requests report the remaining tokens
+	t.Run("rate limit headers", func(t *testing.T) {
+		res := send(t, app, http.MethodGet, "first-token")
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "2", res.Header.Get(middlewares.HeaderRateLimitLimit))
+		assert.Equal(t, "1", res.Header.Get(middlewares.HeaderRateLimitRemaining))
+		assert.Equal(t, "30", res.Header.Get(middlewares.HeaderRateLimitReset))
+		assert.Equal(t, "2;w=60", res.Header.Get(middlewares.HeaderRateLimitPolicy))
+		assert.Empty(t, res.Header.Get(fiber.HeaderRetryAfter))
+	})
+
+	// Test case 2: Requests beyond the limit are rejected
+	t.Run("reject exhausted client", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, send(t, app, http.MethodGet, "first-token").StatusCode)
+
+		res := send(t, app, http.MethodGet, "first-token")
+		assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+		assert.Equal(t, "0", res.Header.Get(middlewares.HeaderRateLimitRemaining))
+		assert.Equal(t, "30", res.Header.Get(fiber.HeaderRetryAfter))
+	})
+
+	// Test case 3: Writes have their own bucket
+	t.Run("separate write limit", func(t *testing.T) {
+		res := send(t, app, http.MethodPost, "first-token")
+		assert.Equal(t, http.StatusCreated, res.StatusCode)
+		assert.Equal(t, "1", res.Header.Get(middlewares.HeaderRateLimitLimit))
+
+		assert.Equal(t, http.StatusTooManyRequests, send(t, app, http.MethodPost, "first-token").StatusCode)
+	})
+
+	// Test case 4: Other clients are not affected
+	t.Run("separate clients", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, send(t, app, http.MethodGet, "second-token").StatusCode)
+	})
+
+	// Test case 5: Unauthenticated requests are limited by address
+	t.Run("limit unauthenticated requests", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, send(t, app, http.MethodGet, "").StatusCode)
+		assert.Equal(t, http.StatusUnauthorized, send(t, app, http.MethodGet, "unknown-token").StatusCode)
+		assert.Equal(t, http.StatusTooManyRequests, send(t, app, http.MethodGet, "").StatusCode)
+	})
+}
+
+// TestRateLimitStoreFailure tests letting requests through while the store fails
+func TestRateLimitStoreFailure(t *testing.T) {
+	app := rateLimitedApp(failingStore{})
+
+	for i := 0; i < 3; i++ {
+		res := send(t, app, http.MethodGet, "first-token")
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Empty(t, res.Header.Get(middlewares.HeaderRateLimitLimit))
+	}
+}
@@ -0,0 +1,30 @@
+package middlewares
+
+import (
+	"net/http"
+	"runtime/debug"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// RecoverHandler turns a panic in a later handler into a jsend 500 carrying
+// the request ID, and logs the panic with its stack. It must be registered
+// after LogHandler, so the 500 is logged and counted, and before
+// SentryHandler, which reports the panic and re-raises it.
+func RecoverHandler(logger *zap.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				utils.Logger(c, logger).Error("recovered from panic while handling request",
+					zap.Any("panic", recovered),
+					zap.ByteString("stack", debug.Stack()),
+				)
+				err = utils.JSONError(c, http.StatusInternalServerError, constants.ErrorInternalServer)
+			}
+		}()
+		return c.Next()
+	}
+}
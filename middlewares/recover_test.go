@@ -0,0 +1,57 @@
+package middlewares_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/middlewares"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestRecoverHandler tests recovering from handler panics
+func TestRecoverHandler(t *testing.T) {
+	core, logs := observer.New(zap.ErrorLevel)
+	logger := zap.New(core)
+
+	app := fiber.New()
+	app.Use(middlewares.RequestIDHandler(logger))
+	app.Use(middlewares.RecoverHandler(logger))
+	app.Get("/panic", func(c *fiber.Ctx) error {
+		panic("handler panicked")
+	})
+
+	t.Run("panic returns jsend 500 with request ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+		req.Header.Set(constants.HeaderRequestID, "request-1")
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+
+		body, err := io.ReadAll(res.Body)
+		assert.Nil(t, err)
+		var response utils.JSONResponse
+		assert.Nil(t, json.Unmarshal(body, &response))
+		assert.Equal(t, "error", response.Status)
+		assert.Equal(t, constants.ErrorInternalServer, response.Message)
+		assert.Equal(t, "request-1", response.RequestID)
+	})
+
+	t.Run("panic is logged with stack", func(t *testing.T) {
+		entries := logs.FilterMessage("recovered from panic while handling request").All()
+		assert.Len(t, entries, 1)
+		if len(entries) == 1 {
+			fields := entries[0].ContextMap()
+			assert.Equal(t, "handler panicked", fields["panic"])
+			assert.Equal(t, "request-1", fields["request_id"])
+			assert.Contains(t, fields["stack"], "runtime/debug.Stack")
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"github.com/valyala/fasthttp"
+)
+
+// redactedValue replaces the logged value of sensitive headers and fields
+const redactedValue = "[REDACTED]"
+
+// logFilter decides what LogHandler writes for a request
+type logFilter struct {
+	headers     map[string]bool
+	fields      map[string]bool
+	maxBodySize int
+	ignorePaths []string
+}
+
+func newLogFilter(cfg config.LoggingConfig) logFilter {
+	filter := logFilter{
+		headers:     map[string]bool{},
+		fields:      map[string]bool{},
+		maxBodySize: cfg.MaxBodySize,
+		ignorePaths: cfg.IgnorePaths,
+	}
+	for _, header := range cfg.RedactHeaders {
+		filter.headers[strings.ToLower(strings.TrimSpace(header))] = true
+	}
+	for _, field := range cfg.RedactFields {
+		filter.fields[strings.ToLower(strings.TrimSpace(field))] = true
+	}
+	return filter
+}
+
+// ignored reports whether requestPath matches one of the ignored path globs
+func (f logFilter) ignored(requestPath string) bool {
+	for _, pattern := range f.ignorePaths {
+		// A malformed pattern only matches itself
+		if matched, err := path.Match(pattern, requestPath); matched || (err != nil && pattern == requestPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestHeaders renders the request headers with sensitive values redacted
+func (f logFilter) requestHeaders(header *fasthttp.RequestHeader) string {
+	var b strings.Builder
+	header.VisitAll(func(key, value []byte) {
+		f.writeHeader(&b, key, value)
+	})
+	return b.String()
+}
+
+// responseHeaders renders the response headers with sensitive values redacted
+func (f logFilter) responseHeaders(header *fasthttp.ResponseHeader) string {
+	var b strings.Builder
+	header.VisitAll(func(key, value []byte) {
+		f.writeHeader(&b, key, value)
+	})
+	return b.String()
+}
+
+func (f logFilter) writeHeader(b *strings.Builder, key, value []byte) {
+	b.Write(key)
+	b.WriteString(": ")
+	if f.headers[strings.ToLower(string(key))] {
+		b.WriteString(redactedValue)
+	} else {
+		b.Write(value)
+	}
+	b.WriteString("\r\n")
+}
+
+// body redacts sensitive fields of a JSON body and truncates it to the
+// maximum body size. JSON bodies are only decoded when they fit within the
+// maximum size, larger ones can't be redacted and are omitted. Bodies that are
+// not JSON are only truncated.
+func (f logFilter) body(body []byte, contentType string) string {
+	if f.maxBodySize <= 0 || len(body) == 0 {
+		return ""
+	}
+
+	if len(f.fields) > 0 && strings.Contains(contentType, "json") {
+		if len(body) > f.maxBodySize {
+			return fmt.Sprintf("...(%d bytes omitted)", len(body))
+		}
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err == nil {
+			if redacted, err := json.Marshal(f.redact(value)); err == nil {
+				body = redacted
+			}
+		}
+	}
+
+	if len(body) <= f.maxBodySize {
+		return string(body)
+	}
+	return fmt.Sprintf("%s...(%d bytes truncated)", body[:f.maxBodySize], len(body)-f.maxBodySize)
+}
+
+// redact replaces the values of sensitive fields at any depth of a decoded JSON value
+func (f logFilter) redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if f.fields[strings.ToLower(key)] {
+				v[key] = redactedValue
+			} else {
+				v[key] = f.redact(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = f.redact(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,82 @@
+package middlewares
+
+import (
+	"testing"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+// TestLogFilter tests redacting, truncating and ignoring what LogHandler logs
+func TestLogFilter(t *testing.T) {
+	filter := newLogFilter(config.LoggingConfig{
+		RedactHeaders: []string{"Authorization", " x-api-key "},
+		RedactFields:  []string{"password", "Token"},
+		MaxBodySize:   1024,
+		IgnorePaths:   []string{"/metrics", "/docs/*", "/[bad"},
+	})
+
+	// Test case 1: Headers are redacted whatever their case
+	t.Run("headers", func(t *testing.T) {
+		var request fasthttp.RequestHeader
+		request.DisableNormalizing()
+		request.Set("AUTHORIZATION", "Bearer secret")
+		request.Set("X-Api-Key", "key")
+		request.Set("Accept", "application/json")
+		headers := filter.requestHeaders(&request)
+		assert.Contains(t, headers, "AUTHORIZATION: [REDACTED]\r\n")
+		assert.Contains(t, headers, "X-Api-Key: [REDACTED]\r\n")
+		assert.Contains(t, headers, "Accept: application/json\r\n")
+		assert.NotContains(t, headers, "secret")
+
+		var response fasthttp.ResponseHeader
+		response.Set("authorization", "Bearer secret")
+		assert.Contains(t, filter.responseHeaders(&response), "Authorization: [REDACTED]\r\n")
+	})
+
+	// Test case 2: JSON fields are redacted at any depth, including in arrays
+	t.Run("json fields", func(t *testing.T) {
+		body := `{"user":{"name":"a","Password":"p"},"tokens":[{"token":"t1"},{"TOKEN":"t2","scope":"read"}],"token":{"nested":"t3"}}`
+		assert.Equal(t,
+			`{"token":"[REDACTED]","tokens":[{"token":"[REDACTED]"},{"TOKEN":"[REDACTED]","scope":"read"}],"user":{"Password":"[REDACTED]","name":"a"}}`,
+			filter.body([]byte(body), "application/json; charset=utf-8"))
+	})
+
+	// Test case 3: Bodies longer than the maximum size are truncated
+	t.Run("truncation", func(t *testing.T) {
+		small := newLogFilter(config.LoggingConfig{RedactFields: []string{"password"}, MaxBodySize: 30})
+		assert.Equal(t, "012345678901234567890123456789...(6 bytes truncated)", small.body([]byte("012345678901234567890123456789abcdef"), "text/plain"))
+		assert.Equal(t, "012345678901234567890123456789", small.body([]byte("012345678901234567890123456789"), "text/plain"))
+		// JSON bodies over the maximum size aren't decoded, so they are omitted
+		// rather than truncated with their sensitive fields
+		assert.Equal(t, "...(45 bytes omitted)", small.body([]byte(`{"password":"a-secret-longer-than-the-limit"}`), "application/json"))
+		assert.Equal(t, `{"password":"[REDACTED]"}`, small.body([]byte(`{"password":"short-secret"}`), "application/json"))
+
+		disabled := newLogFilter(config.LoggingConfig{MaxBodySize: 0})
+		assert.Equal(t, "", disabled.body([]byte("body"), "text/plain"))
+	})
+
+	// Test case 4: Bodies that are not JSON are logged as they are
+	t.Run("non-json bodies", func(t *testing.T) {
+		assert.Equal(t, `password=secret`, filter.body([]byte(`password=secret`), "application/x-www-form-urlencoded"))
+		assert.Equal(t, `{"password":`, filter.body([]byte(`{"password":`), "application/json"))
+		assert.Equal(t, `{"password":"secret"}`, filter.body([]byte(`{"password":"secret"}`), "text/plain"))
+	})
+
+	// Test case 5: Paths are ignored by exact match or glob, and a malformed
+	// pattern only matches itself
+	t.Run("ignored paths", func(t *testing.T) {
+		for requestPath, ignored := range map[string]bool{
+			"/metrics":       true,
+			"/metrics/extra": false,
+			"/docs/index":    true,
+			"/docs/a/b":      false,
+			"/[bad":          true,
+			"/b":             false,
+			"/api/v1/apps":   false,
+		} {
+			assert.Equal(t, ignored, filter.ignored(requestPath), requestPath)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package middlewares
+
+import (
+	"strings"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RequestIDHandler accepts the X-Request-ID of the client or generates one,
+// echoes it in the response and stores a logger carrying it in the request
+// locals. It must be registered first so every later log line and error
+// response can be correlated with the request.
+func RequestIDHandler(logger *zap.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(constants.HeaderRequestID)
+		if validRequestID(id) {
+			// fiber strings point into reused buffers, but the logger outlives the request
+			id = strings.Clone(id)
+		} else {
+			id = uuid.NewString()
+		}
+
+		c.Set(constants.HeaderRequestID, id)
+		c.Locals(constants.LocalRequestID, id)
+		c.Locals(constants.LocalLogger, logger.With(zap.String("request_id", id)))
+		return c.Next()
+	}
+}
+
+// validRequestID rejects empty, oversized and non printable IDs so clients
+// cannot inject arbitrary content into logs and response headers.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > constants.MaxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package middlewares
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/helmet"
+)
+
+// SwaggerPath is where the swagger UI is served
+const SwaggerPath = "/swagger"
+
+// Content security policies. API responses are JSON and never render as a
+// page, the swagger UI needs its own scripts, inline styles and the
+// configuration script inlined in its index page.
+const (
+	apiCSP     = "default-src 'none'; frame-ancestors 'none'"
+	swaggerCSP = "default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; img-src 'self' data:; frame-ancestors 'none'"
+)
+
+// exposedHeaders are the response headers browser clients may read
+var exposedHeaders = []string{
+	HeaderRateLimitLimit,
+	HeaderRateLimitRemaining,
+	HeaderRateLimitReset,
+	HeaderRateLimitPolicy,
+	fiber.HeaderRetryAfter,
+	constants.HeaderRequestID,
+}
+
+// CORSHandler answers preflight requests and sets the CORS headers for the
+// origins in cfg. It returns nil when no origin is allowed.
+func CORSHandler(cfg config.CORSConfig) (fiber.Handler, error) {
+	if len(cfg.AllowOrigins) == 0 {
+		return nil, nil
+	}
+	if cfg.AllowCredentials && slices.Contains(cfg.AllowOrigins, "*") {
+		return nil, errors.New("cors: credentials can't be allowed for the * origin")
+	}
+
+	return cors.New(cors.Config{
+		AllowOrigins:     strings.Join(cfg.AllowOrigins, ","),
+		AllowMethods:     strings.Join(cfg.AllowMethods, ","),
+		AllowHeaders:     strings.Join(cfg.AllowHeaders, ","),
+		AllowCredentials: cfg.AllowCredentials,
+		ExposeHeaders:    strings.Join(exposedHeaders, ","),
+		MaxAge:           int(cfg.MaxAge.Seconds()),
+	}), nil
+}
+
+// SecurityHeaders sets the standard security headers on every response, with
+// a content security policy relaxed just enough for the swagger UI
+func SecurityHeaders(cfg config.HTTPConfig) fiber.Handler {
+	headers := helmet.Config{
+		XFrameOptions: "DENY",
+		HSTSMaxAge:    int(cfg.HSTSMaxAge.Seconds()),
+	}
+
+	apiHeaders := headers
+	apiHeaders.ContentSecurityPolicy = apiCSP
+	swaggerHeaders := headers
+	swaggerHeaders.ContentSecurityPolicy = swaggerCSP
+	api, swagger := helmet.New(apiHeaders), helmet.New(swaggerHeaders)
+
+	return func(c *fiber.Ctx) error {
+		if c.Path() == SwaggerPath || strings.HasPrefix(c.Path(), SwaggerPath+"/") {
+			return swagger(c)
+		}
+		return api(c)
+	}
+}
@@ -0,0 +1,125 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/middlewares"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+const dashboardOrigin = "https://dashboard.example.com"
+
+func corsConfig() config.CORSConfig {
+	return config.CORSConfig{
+		AllowOrigins: []string{dashboardOrigin},
+		AllowMethods: []string{fiber.MethodGet, fiber.MethodPost},
+		AllowHeaders: []string{fiber.HeaderAuthorization, middlewares.HeaderAPIKey},
+		MaxAge:       10 * time.Minute,
+	}
+}
+
+// TestCORSHandler tests answering cross-origin requests
+func TestCORSHandler(t *testing.T) {
+	handler, err := middlewares.CORSHandler(corsConfig())
+	assert.Nil(t, err)
+
+	app := fiber.New()
+	app.Use(handler)
+	app.Get("/api/v1/apps", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+
+	// Test case 1: Preflight from an allowed origin
+	t.Run("preflight allowed origin", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/api/v1/apps", nil)
+		req.Header.Set(fiber.HeaderOrigin, dashboardOrigin)
+		req.Header.Set(fiber.HeaderAccessControlRequestMethod, http.MethodGet)
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+		assert.Equal(t, dashboardOrigin, res.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+		assert.Equal(t, "GET,POST", res.Header.Get(fiber.HeaderAccessControlAllowMethods))
+		assert.Equal(t, "Authorization,X-API-Key", res.Header.Get(fiber.HeaderAccessControlAllowHeaders))
+		assert.Equal(t, "600", res.Header.Get(fiber.HeaderAccessControlMaxAge))
+	})
+
+	// Test case 2: Rate limit headers are readable by the dashboard
+	t.Run("expose headers", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/apps", nil)
+		req.Header.Set(fiber.HeaderOrigin, dashboardOrigin)
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		assert.Equal(t, dashboardOrigin, res.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+		assert.Contains(t, res.Header.Get(fiber.HeaderAccessControlExposeHeaders), middlewares.HeaderRateLimitRemaining)
+	})
+
+	// Test case 3: Other origins get no CORS headers
+	t.Run("reject other origin", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/apps", nil)
+		req.Header.Set(fiber.HeaderOrigin, "https://evil.example.com")
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		assert.Empty(t, res.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+	})
+
+	// Test case 4: CORS is disabled without origins
+	t.Run("disabled", func(t *testing.T) {
+		handler, err := middlewares.CORSHandler(config.CORSConfig{})
+		assert.Nil(t, err)
+		assert.Nil(t, handler)
+	})
+
+	// Test case 5: Credentials can't be allowed for every origin
+	t.Run("reject credentials with wildcard", func(t *testing.T) {
+		cfg := corsConfig()
+		cfg.AllowOrigins = []string{"*"}
+		cfg.AllowCredentials = true
+		_, err := middlewares.CORSHandler(cfg)
+		assert.NotNil(t, err)
+	})
+}
+
+// TestSecurityHeaders tests the security headers of api and swagger responses
+func TestSecurityHeaders(t *testing.T) {
+	app := fiber.New()
+	app.Use(middlewares.SecurityHeaders(config.HTTPConfig{HSTSMaxAge: 24 * time.Hour}))
+	app.Get("/api/v1/apps", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+	app.Get("/swagger/*", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+
+	// Test case 1: API responses
+	t.Run("api headers", func(t *testing.T) {
+		res, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/apps", nil))
+		assert.Nil(t, err)
+		assert.Equal(t, "nosniff", res.Header.Get(fiber.HeaderXContentTypeOptions))
+		assert.Equal(t, "DENY", res.Header.Get(fiber.HeaderXFrameOptions))
+		assert.Equal(t, "default-src 'none'; frame-ancestors 'none'", res.Header.Get(fiber.HeaderContentSecurityPolicy))
+		// HSTS is only sent over HTTPS
+		assert.Empty(t, res.Header.Get(fiber.HeaderStrictTransportSecurity))
+	})
+
+	// Test case 2: HSTS behind a TLS terminating proxy
+	t.Run("hsts over https", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/apps", nil)
+		req.Header.Set(fiber.HeaderXForwardedProto, "https")
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		assert.Equal(t, "max-age=86400; includeSubDomains", res.Header.Get(fiber.HeaderStrictTransportSecurity))
+	})
+
+	// Test case 3: The swagger UI may run its own scripts
+	t.Run("swagger headers", func(t *testing.T) {
+		res, err := app.Test(httptest.NewRequest(http.MethodGet, "/swagger/index.html", nil))
+		assert.Nil(t, err)
+		assert.Contains(t, res.Header.Get(fiber.HeaderContentSecurityPolicy), "script-src 'self' 'unsafe-inline'")
+		assert.Equal(t, "nosniff", res.Header.Get(fiber.HeaderXContentTypeOptions))
+	})
+}
@@ -0,0 +1,68 @@
+package middlewares
+
+import (
+	"fmt"
+	"strings"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+)
+
+// SentryHandler reports panics and 5xx responses to Sentry with the request,
+// its request ID and the authenticated user attached. Each request gets its
+// own hub, stored in the user context, so handlers can add breadcrumbs with
+// sentry.GetHubFromContext. Panics are re-raised for the recovery middleware.
+// It must be registered after LogHandler to see the errors of handlers.
+func SentryHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		hub := sentry.CurrentHub().Clone()
+		hub.Scope().SetTag("request_id", utils.RequestID(c))
+		c.SetUserContext(sentry.SetHubOnContext(c.UserContext(), hub))
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				setSentryRequest(c, hub)
+				hub.RecoverWithContext(c.UserContext(), recovered)
+				panic(recovered)
+			}
+		}()
+
+		err := c.Next()
+
+		status := responseStatus(c, err)
+		if status < fiber.StatusInternalServerError {
+			return err
+		}
+		setSentryRequest(c, hub)
+		hub.Scope().SetTag("status_code", fmt.Sprint(status))
+		if err != nil {
+			hub.CaptureException(err)
+		} else {
+			// Handlers report failures through the response, so name the
+			// event after the route to group it with the same failures
+			hub.CaptureMessage(fmt.Sprintf("%s %s responded %d", c.Method(), c.Route().Path, status))
+		}
+		return err
+	}
+}
+
+// setSentryRequest attaches the request and its authenticated user to the
+// scope. It is only called when reporting, to avoid converting every request.
+// The client IP is only sent when default PII is allowed.
+func setSentryRequest(c *fiber.Ctx, hub *sentry.Hub) {
+	if request, err := adaptor.ConvertRequest(c, true); err == nil {
+		hub.Scope().SetRequest(request)
+	}
+
+	user := sentry.User{}
+	if id, ok := c.Locals(constants.LocalUserID).(string); ok {
+		user.ID = id
+	}
+	if client := hub.Client(); client != nil && client.Options().SendDefaultPII {
+		user.IPAddress = strings.Clone(c.IP())
+	}
+	hub.Scope().SetUser(user)
+}
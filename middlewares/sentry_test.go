@@ -0,0 +1,134 @@
+package middlewares_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/middlewares"
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// fakeTransport keeps events in memory instead of sending them to Sentry
+type fakeTransport struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (t *fakeTransport) Configure(options sentry.ClientOptions) {}
+
+func (t *fakeTransport) Flush(timeout time.Duration) bool {
+	return true
+}
+
+func (t *fakeTransport) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, event)
+}
+
+func (t *fakeTransport) Events() []*sentry.Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	events := t.events
+	t.events = nil
+	return events
+}
+
+func newSentryApp(t *testing.T) (*fiber.App, *fakeTransport) {
+	transport := &fakeTransport{}
+	err := sentry.Init(sentry.ClientOptions{
+		Dsn:       "https://public@sentry.example.com/1",
+		Transport: transport,
+	})
+	assert.Nil(t, err)
+
+	app := fiber.New()
+	app.Use(recover.New())
+	app.Use(middlewares.RequestIDHandler(zap.NewNop()))
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(constants.LocalUserID, "user-1")
+		return c.Next()
+	})
+	app.Use(middlewares.SentryHandler())
+
+	app.Get("/failure", func(c *fiber.Ctx) error {
+		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"status": "error"})
+	})
+	app.Get("/error", func(c *fiber.Ctx) error {
+		return errors.New("handler failed")
+	})
+	app.Get("/panic", func(c *fiber.Ctx) error {
+		panic("handler panicked")
+	})
+	app.Get("/missing", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusNotFound)
+	})
+	return app, transport
+}
+
+// TestSentryHandler tests reporting of failed requests to Sentry
+func TestSentryHandler(t *testing.T) {
+	app, transport := newSentryApp(t)
+
+	t.Run("5xx response is reported with request context", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/failure", nil)
+		req.Header.Set(constants.HeaderRequestID, "request-1")
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+
+		events := transport.Events()
+		assert.Len(t, events, 1)
+		if len(events) == 1 {
+			assert.Equal(t, "GET /failure responded 500", events[0].Message)
+			assert.Equal(t, "request-1", events[0].Tags["request_id"])
+			assert.Equal(t, "500", events[0].Tags["status_code"])
+			assert.Equal(t, "user-1", events[0].User.ID)
+			assert.Empty(t, events[0].User.IPAddress)
+			assert.NotNil(t, events[0].Request)
+			assert.Contains(t, events[0].Request.URL, "/failure")
+		}
+	})
+
+	t.Run("handler error is reported as exception", func(t *testing.T) {
+		res, err := app.Test(httptest.NewRequest(http.MethodGet, "/error", nil))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+
+		events := transport.Events()
+		assert.Len(t, events, 1)
+		if len(events) == 1 {
+			assert.Len(t, events[0].Exception, 1)
+			assert.Equal(t, "handler failed", events[0].Exception[0].Value)
+		}
+	})
+
+	t.Run("panic is reported and re-raised", func(t *testing.T) {
+		res, err := app.Test(httptest.NewRequest(http.MethodGet, "/panic", nil))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+
+		events := transport.Events()
+		assert.Len(t, events, 1)
+		if len(events) == 1 {
+			assert.Equal(t, "handler panicked", events[0].Message)
+			assert.Equal(t, "user-1", events[0].User.ID)
+		}
+	})
+
+	t.Run("4xx response is not reported", func(t *testing.T) {
+		res, err := app.Test(httptest.NewRequest(http.MethodGet, "/missing", nil))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		assert.Empty(t, transport.Events())
+	})
+}
@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"errors"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/kratos"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// sessionPrincipal authenticates the caller by a Kratos session, the identity
+// ID becomes the user ID recorded for the request
+func (m Middleware) sessionPrincipal(c *fiber.Ctx, credentials kratos.Credentials) (principal, error) {
+	identity, err := m.credentials.Sessions.Whoami(c.UserContext(), credentials)
+	if errors.Is(err, kratos.ErrUnauthenticated) {
+		utils.Logger(c, m.logger).Warn("rejected request without active session", zap.String("path", c.Path()))
+		return principal{}, errUnauthenticated
+	}
+	if err != nil {
+		return principal{}, err
+	}
+	return identityPrincipal(identity.ID, identity.Roles), nil
+}
@@ -0,0 +1,83 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/middlewares"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/models"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/kratos"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// TestSessionAuth tests authenticating requests with Kratos sessions
+func TestSessionAuth(t *testing.T) {
+	// Fake Kratos knowing a single viewer session
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("ory_kratos_session")
+		if (err != nil || cookie.Value != "viewer-session") && r.Header.Get(kratos.HeaderSessionToken) != "ory_st_viewer" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"active":true,"identity":{"id":"identity-1","metadata_public":{"roles":["viewer"]}}}`))
+	}))
+	defer server.Close()
+
+	sessions := kratos.New(config.KratosConfig{
+		WhoamiURL:     server.URL + "/sessions/whoami",
+		SessionCookie: "ory_kratos_session",
+		CacheTTL:      time.Minute,
+		Timeout:       time.Second,
+	})
+	middleware := middlewares.NewMiddleware(config.AppConfig{}, middlewares.Credentials{APIKeys: noAPIKeys{}, Sessions: sessions}, nil, nil, zap.NewNop())
+
+	app := fiber.New()
+	app.Get("/apps", middleware.Authorize(models.ScopeAppsRead), func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+	app.Delete("/apps", middleware.Authorize(models.ScopeAppsWrite), func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+
+	// Test case 1: Session cookie
+	t.Run("read with session cookie", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/apps", nil)
+		req.AddCookie(&http.Cookie{Name: "ory_kratos_session", Value: "viewer-session"})
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	// Test case 2: Session token as bearer token
+	t.Run("read with session token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/apps", nil)
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer ory_st_viewer")
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	// Test case 3: Viewer session can't write
+	t.Run("write with viewer session", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/apps", nil)
+		req.Header.Set(kratos.HeaderSessionToken, "ory_st_viewer")
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+
+	// Test case 4: Unknown session
+	t.Run("unknown session", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/apps", nil)
+		req.AddCookie(&http.Cookie{Name: "ory_kratos_session", Value: "logged-out"})
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+}
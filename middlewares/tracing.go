@@ -0,0 +1,78 @@
+package middlewares
+
+import (
+	"strings"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/tracing"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AttributeRequestID is the span attribute holding the X-Request-ID of a request
+const AttributeRequestID = attribute.Key("http.request.id")
+
+// headerCarrier adapts fiber request headers to a propagation.TextMapCarrier
+type headerCarrier struct {
+	ctx *fiber.Ctx
+}
+
+func (h headerCarrier) Get(key string) string {
+	return h.ctx.Get(key)
+}
+
+func (h headerCarrier) Set(key, value string) {
+	h.ctx.Request().Header.Set(key, value)
+}
+
+func (h headerCarrier) Keys() []string {
+	keys := []string{}
+	h.ctx.Request().Header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
+
+// TraceHandler starts a server span for each request, continuing the trace of
+// an incoming W3C traceparent header. The span context is stored as the user
+// context so database queries made with c.UserContext() become child spans.
+// It must be registered before LogHandler so the final status code is seen.
+func TraceHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// fiber strings point into reused buffers, but spans outlive the request
+		method := strings.Clone(c.Method())
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), headerCarrier{c})
+		ctx, span := tracing.Tracer().Start(ctx, method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(method),
+				semconv.URLPath(strings.Clone(c.Path())),
+			),
+		)
+		defer span.End()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		if route, ok := matchedRoute(c, err); ok {
+			span.SetName(method + " " + route)
+			span.SetAttributes(semconv.HTTPRoute(route))
+		}
+		status := responseStatus(c, err)
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if id := utils.RequestID(c); id != "" {
+			span.SetAttributes(AttributeRequestID.String(strings.Clone(id)))
+		}
+		if status >= fiber.StatusInternalServerError {
+			if err != nil {
+				span.RecordError(err)
+			}
+			span.SetStatus(codes.Error, "")
+		}
+		return err
+	}
+}
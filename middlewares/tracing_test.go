@@ -0,0 +1,145 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/database"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/middlewares"
+	pMetrics "git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/prometheus"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/doug-martin/goqu/v9"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// TestTraceHandler tests server spans and their database child spans
+func TestTraceHandler(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	mockDB, mock, err := sqlmock.NewWithDSN("trace-handler")
+	assert.Nil(t, err)
+	defer mockDB.Close()
+	db, err := database.OpenDB("postgres", "sqlmock", "trace-handler")
+	assert.Nil(t, err)
+
+	logger := zap.NewNop()
+	app := fiber.New()
+	app.Use(middlewares.RequestIDHandler(logger))
+	app.Use(middlewares.TraceHandler())
+	app.Use(middlewares.LogHandler(logger, nil, pMetrics.InitPrometheusMetrics(config.MetricsConfig{}), config.LoggingConfig{}))
+	// Middlewares registered after LogHandler become the current route too
+	app.Use(middlewares.RecoverHandler(logger))
+	app.Get("/traced/:name", func(c *fiber.Ctx) error {
+		var names []string
+		if err := db.From("apps").Select("name").Where(goqu.C("name").Eq(c.Params("name"))).ScanValsContext(c.UserContext(), &names); err != nil {
+			return err
+		}
+		return c.JSON(names)
+	})
+
+	spanByKind := func(kind trace.SpanKind) (tracetest.SpanStub, bool) {
+		for _, span := range exporter.GetSpans() {
+			if span.SpanKind == kind {
+				return span, true
+			}
+		}
+		return tracetest.SpanStub{}, false
+	}
+	attributes := func(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+		values := map[attribute.Key]attribute.Value{}
+		for _, kv := range span.Attributes {
+			values[kv.Key] = kv.Value
+		}
+		return values
+	}
+
+	// Test case 1: the server span continues the traceparent of the request,
+	// is named by route and has a child span for each query
+	t.Run("traceparent is continued", func(t *testing.T) {
+		exporter.Reset()
+		mock.ExpectQuery(`SELECT "name" FROM "apps" WHERE \("name" = 'Maps'\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("Maps"))
+
+		req := httptest.NewRequest(http.MethodGet, "/traced/Maps", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		req.Header.Set(constants.HeaderRequestID, "request-1")
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Nil(t, mock.ExpectationsWereMet())
+
+		server, ok := spanByKind(trace.SpanKindServer)
+		assert.True(t, ok)
+		assert.Equal(t, "GET /traced/:name", server.Name)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.Parent.TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", server.Parent.SpanID().String())
+		assert.True(t, server.Parent.IsRemote())
+		assert.Equal(t, server.Parent.TraceID(), server.SpanContext.TraceID())
+		serverAttributes := attributes(server)
+		assert.Equal(t, "/traced/:name", serverAttributes[semconv.HTTPRouteKey].AsString())
+		assert.Equal(t, "request-1", serverAttributes[middlewares.AttributeRequestID].AsString())
+		assert.Equal(t, int64(http.StatusOK), serverAttributes[semconv.HTTPResponseStatusCodeKey].AsInt64())
+
+		query, ok := spanByKind(trace.SpanKindClient)
+		assert.True(t, ok)
+		assert.Equal(t, server.SpanContext.SpanID(), query.Parent.SpanID())
+		assert.Equal(t, server.SpanContext.TraceID(), query.SpanContext.TraceID())
+		queryAttributes := attributes(query)
+		assert.Equal(t, `SELECT "name" FROM "apps" WHERE ("name" = 'Maps')`, queryAttributes[semconv.DBQueryTextKey].AsString())
+		assert.Equal(t, "postgres", queryAttributes[semconv.DBSystemKey].AsString())
+	})
+
+	// Test case 2: a malformed traceparent starts a new trace
+	t.Run("malformed traceparent starts a new trace", func(t *testing.T) {
+		exporter.Reset()
+		mock.ExpectQuery(`SELECT "name" FROM "apps"`).
+			WillReturnRows(sqlmock.NewRows([]string{"name"}))
+
+		req := httptest.NewRequest(http.MethodGet, "/traced/Maps", nil)
+		req.Header.Set("traceparent", "not-a-traceparent")
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		server, ok := spanByKind(trace.SpanKindServer)
+		assert.True(t, ok)
+		assert.False(t, server.Parent.IsValid())
+		assert.True(t, server.SpanContext.IsValid())
+	})
+
+	// Test case 3: unknown paths are not named after a later middleware
+	t.Run("unknown path", func(t *testing.T) {
+		exporter.Reset()
+
+		res, err := app.Test(httptest.NewRequest(http.MethodGet, "/untraced", nil))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+		server, ok := spanByKind(trace.SpanKindServer)
+		assert.True(t, ok)
+		assert.Equal(t, http.MethodGet, server.Name)
+		_, hasRoute := attributes(server)[semconv.HTTPRouteKey]
+		assert.False(t, hasRoute)
+		assert.Equal(t, int64(http.StatusNotFound), attributes(server)[semconv.HTTPResponseStatusCodeKey].AsInt64())
+	})
+}
@@ -0,0 +1,178 @@
+package models
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/database"
+	"github.com/doug-martin/goqu/v9"
+	"github.com/lib/pq"
+)
+
+// APIKeyTable represent table name
+const APIKeyTable = "api_keys"
+
+// API key scopes
+const (
+	ScopeAppsRead     = "apps:read"
+	ScopeAppsWrite    = "apps:write"
+	ScopeReviewsWrite = "reviews:write"
+	// ScopeAdmin grants every other scope
+	ScopeAdmin = "admin"
+)
+
+// Scopes lists every valid API key scope
+var Scopes = []string{ScopeAppsRead, ScopeAppsWrite, ScopeReviewsWrite, ScopeAdmin}
+
+// apiKeyPrefix marks keys issued by this service, so leaked keys are easy to grep for
+const apiKeyPrefix = "fca_"
+
+// apiKeyDisplayLength is how many leading characters of a key are stored in
+// clear to tell keys apart when listing them
+const apiKeyDisplayLength = len(apiKeyPrefix) + 8
+
+// APIKey model. Only the SHA-256 hash of a key is stored, the key itself is
+// shown once when it is created.
+type APIKey struct {
+	ID        int            `json:"id" db:"id"`
+	Name      string         `json:"name" db:"name"`
+	Prefix    string         `json:"prefix" db:"prefix"`
+	KeyHash   string         `json:"-" db:"key_hash"`
+	Scopes    pq.StringArray `json:"scopes" db:"scopes"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+	RevokedAt sql.NullTime   `json:"-" db:"revoked_at"`
+}
+
+// HasScope reports whether the key grants scope
+func (key APIKey) HasScope(scope string) bool {
+	return slices.Contains(key.Scopes, scope) || slices.Contains(key.Scopes, ScopeAdmin)
+}
+
+// Revoked reports whether the key was revoked
+func (key APIKey) Revoked() bool {
+	return key.RevokedAt.Valid
+}
+
+// APIKeyModel implements api key related database operations
+type APIKeyModel struct {
+	db *goqu.Database
+}
+
+// InitAPIKeyModel Init model
+func InitAPIKeyModel(goqu *goqu.Database) (APIKeyModel, error) {
+	return APIKeyModel{
+		db: goqu,
+	}, nil
+}
+
+// HashAPIKey returns the hex encoded SHA-256 hash a key is stored as. Keys are
+// random, so a fast hash is enough to make a leaked table useless.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidateScopes returns an error naming the first unknown scope
+func ValidateScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return fmt.Errorf("at least one scope is required, expected some of %v", Scopes)
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(Scopes, scope) {
+			return fmt.Errorf("unknown scope %q, expected one of %v", scope, Scopes)
+		}
+	}
+	return nil
+}
+
+// CreateAPIKey generates and stores a new key. The returned key is the only
+// time it is available in clear.
+func (model *APIKeyModel) CreateAPIKey(ctx context.Context, name string, scopes []string) (APIKey, string, error) {
+	ctx = database.WithOperation(ctx, "api_keys.create")
+	if err := ValidateScopes(scopes); err != nil {
+		return APIKey{}, "", err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return APIKey{}, "", err
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	apiKey := APIKey{
+		Name:    name,
+		Prefix:  key[:apiKeyDisplayLength],
+		KeyHash: HashAPIKey(key),
+		Scopes:  scopes,
+	}
+	_, err := model.db.Insert(APIKeyTable).
+		Rows(goqu.Record{
+			"name":     apiKey.Name,
+			"prefix":   apiKey.Prefix,
+			"key_hash": apiKey.KeyHash,
+			"scopes":   apiKey.Scopes,
+		}).
+		Returning(goqu.Star()).
+		Executor().
+		ScanStructContext(ctx, &apiKey)
+	if err != nil {
+		return APIKey{}, "", err
+	}
+	return apiKey, key, nil
+}
+
+// GetAPIKeys lists all keys, including revoked ones
+func (model *APIKeyModel) GetAPIKeys(ctx context.Context) ([]APIKey, error) {
+	ctx = database.WithOperation(ctx, "api_keys.list")
+	var keys []APIKey
+	if err := model.db.From(APIKeyTable).Order(goqu.C("id").Asc()).ScanStructsContext(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// GetAPIKeyByKey looks up an active key by its clear value
+func (model *APIKeyModel) GetAPIKeyByKey(ctx context.Context, key string) (APIKey, error) {
+	ctx = database.WithOperation(ctx, "api_keys.get_by_key")
+	apiKey := APIKey{}
+	found, err := model.db.From(APIKeyTable).Where(goqu.Ex{
+		"key_hash":   HashAPIKey(key),
+		"revoked_at": nil,
+	}).ScanStructContext(ctx, &apiKey)
+
+	if err != nil {
+		return apiKey, err
+	}
+
+	if !found {
+		return apiKey, sql.ErrNoRows
+	}
+
+	return apiKey, nil
+}
+
+// RevokeAPIKey revokes the key with id, revoking a key twice is not an error
+func (model *APIKeyModel) RevokeAPIKey(ctx context.Context, id int) error {
+	ctx = database.WithOperation(ctx, "api_keys.revoke")
+	result, err := model.db.Update(APIKeyTable).Set(goqu.Record{
+		"revoked_at": goqu.L("COALESCE(revoked_at, NOW())"),
+	}).Where(goqu.Ex{"id": id}).Executor().ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -1,9 +1,15 @@
 package models
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"sort"
 
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/database"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
 	"github.com/doug-martin/goqu/v9"
+	"github.com/samber/lo"
 )
 
 // AppTable represent table name
@@ -14,13 +20,13 @@ type App struct {
 	AppId         int     `json:"id" db:"id"`
 	App           string  `json:"app" db:"app" validate:"required"`
 	Category      string  `json:"category" db:"category" validate:"required"`
-	Rating        float64 `json:"rating" db:"rating" validate:"required"`
-	Reviews       int     `json:"reviews" db:"reviews" validate:"required"`
+	Rating        float64 `json:"rating" db:"rating" validate:"gte=0,lte=5"`
+	Reviews       int     `json:"reviews" db:"reviews" validate:"gte=0"`
 	Size          string  `json:"size" db:"size" validate:"required"`
 	Installs      string  `json:"installs" db:"installs" validate:"required"`
-	Type          string  `json:"type" db:"type" validate:"required"`
+	Type          string  `json:"type" db:"type" validate:"required,oneof=Free Paid"`
 	Price         string  `json:"price" db:"price" validate:"required"`
-	ContentRating string  `json:"content_rating" db:"content_rating" validate:"required"`
+	ContentRating string  `json:"content_rating" db:"content_rating" validate:"required,content_rating"`
 	Genres        string  `json:"genres" db:"genres" validate:"required"`
 	LastUpdated   string  `json:"last_updated" db:"last_updated" validate:"required"`
 	CurrentVer    string  `json:"current_ver" db:"current_ver" validate:"required"`
@@ -40,7 +46,8 @@ func InitAppModel(goqu *goqu.Database) (AppModel, error) {
 }
 
 // GetApps lists all apps.
-func (model *AppModel) GetApps(limit, offset int) ([]App, error) {
+func (model *AppModel) GetApps(ctx context.Context, limit, offset int) ([]App, error) {
+	ctx = database.WithOperation(ctx, "apps.list")
 	var apps []App
 	query := model.db.From(AppTable)
 
@@ -51,18 +58,19 @@ func (model *AppModel) GetApps(limit, offset int) ([]App, error) {
 		query = query.Offset(uint(offset))
 	}
 
-	if err := query.ScanStructs(&apps); err != nil {
+	if err := query.ScanStructsContext(ctx, &apps); err != nil {
 		return nil, err
 	}
 	return apps, nil
 }
 
 // GetById gets an app by its ID.  It retrieves all fields from the database.
-func (model *AppModel) GetAppById(id int) (App, error) {
+func (model *AppModel) GetAppById(ctx context.Context, id int) (App, error) {
+	ctx = database.WithOperation(ctx, "apps.get")
 	app := App{}
 	found, err := model.db.From(AppTable).Where(goqu.Ex{
 		"id": id,
-	}).ScanStruct(&app)
+	}).ScanStructContext(ctx, &app)
 
 	if err != nil {
 		return app, err
@@ -78,7 +86,8 @@ func (model *AppModel) GetAppById(id int) (App, error) {
 // InsertApps inserts a new app into the database.
 // For AppModel with database-generated ID (SERIAL)
 // InsertApps inserts a new app into the database.
-func (model *AppModel) InsertApps(app App) (App, error) {
+func (model *AppModel) InsertApps(ctx context.Context, app App) (App, error) {
+	ctx = database.WithOperation(ctx, "apps.insert")
 	var insertedID int64
 
 	_, err := model.db.Insert(AppTable).
@@ -99,7 +108,7 @@ func (model *AppModel) InsertApps(app App) (App, error) {
 		}).
 		Returning("id"). // This makes PostgreSQL return the inserted ID
 		Executor().
-		ScanVal(&insertedID)
+		ScanValContext(ctx, &insertedID)
 
 	if err != nil {
 		return App{}, err
@@ -109,10 +118,11 @@ func (model *AppModel) InsertApps(app App) (App, error) {
 	return app, nil
 }
 
-func (model *AppModel) DeleteApp(id int) error {
+func (model *AppModel) DeleteApp(ctx context.Context, id int) error {
+	ctx = database.WithOperation(ctx, "apps.delete")
 	result, err := model.db.Delete(AppTable).Where(goqu.Ex{
 		"id": id,
-	}).Executor().Exec()
+	}).Executor().ExecContext(ctx)
 	if err != nil {
 		return err
 	}
@@ -126,7 +136,8 @@ func (model *AppModel) DeleteApp(id int) error {
 	return nil
 }
 
-func (model *AppModel) UpdateApp(id int, app App) (App, error) {
+func (model *AppModel) UpdateApp(ctx context.Context, id int, app App) (App, error) {
+	ctx = database.WithOperation(ctx, "apps.update")
 	result, err := model.db.Update(AppTable).Set(goqu.Record{
 		"app":            app.App,
 		"category":       app.Category,
@@ -141,7 +152,7 @@ func (model *AppModel) UpdateApp(id int, app App) (App, error) {
 		"last_updated":   app.LastUpdated,
 		"current_ver":    app.CurrentVer,
 		"android_ver":    app.AndroidVer,
-	}).Where(goqu.Ex{"id": id}).Executor().Exec()
+	}).Where(goqu.Ex{"id": id}).Executor().ExecContext(ctx)
 	if err != nil {
 		return App{}, err
 	}
@@ -157,3 +168,208 @@ func (model *AppModel) UpdateApp(id int, app App) (App, error) {
 	app.AppId = id
 	return app, nil
 }
+
+// Duplicate match modes
+const (
+	DuplicateMatchExact = "exact"
+	DuplicateMatchFuzzy = "fuzzy"
+)
+
+// DuplicateSimilarityThreshold is the minimum name similarity for a fuzzy match
+const DuplicateSimilarityThreshold = 0.9
+
+// ErrMergeAppNotFound is returned when a duplicate to merge does not exist
+var ErrMergeAppNotFound = errors.New("duplicate app not found")
+
+// ErrInvalidMerge is returned when the canonical app is also listed as a duplicate
+var ErrInvalidMerge = errors.New("canonical app cannot be merged into itself")
+
+// DuplicateGroup is a set of apps in the same category that look like copies of one app.
+type DuplicateGroup struct {
+	Match      string `json:"match"`
+	Canonical  App    `json:"canonical"`
+	Duplicates []App  `json:"duplicates"`
+}
+
+// MergeResult describes the outcome of merging duplicates into a canonical app.
+type MergeResult struct {
+	Canonical        App   `json:"canonical"`
+	DeletedIDs       []int `json:"deleted_ids"`
+	ReviewsRepointed int64 `json:"reviews_repointed"`
+}
+
+// GetDuplicateApps groups apps that share a category and either the exact
+// name or, for fuzzy matching, a similar normalized name. The canonical app
+// of a group is the one with the most reviews, then the lowest ID.
+func (model *AppModel) GetDuplicateApps(ctx context.Context, match string) ([]DuplicateGroup, error) {
+	ctx = database.WithOperation(ctx, "apps.duplicates")
+	var apps []App
+	if err := model.db.From(AppTable).Order(goqu.C("id").Asc()).ScanStructsContext(ctx, &apps); err != nil {
+		return nil, err
+	}
+
+	parent := make([]int, len(apps))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(i, j int) {
+		if ri, rj := find(i), find(j); ri != rj {
+			parent[rj] = ri
+		}
+	}
+
+	// Apps sharing a category and a name key are always duplicates
+	byKey := map[string]int{}
+	for i, app := range apps {
+		name := app.App
+		if match == DuplicateMatchFuzzy {
+			name = utils.NormalizeName(name)
+		}
+		key := app.Category + "\x00" + name
+		if first, ok := byKey[key]; ok {
+			union(first, i)
+			continue
+		}
+		byKey[key] = i
+	}
+
+	if match == DuplicateMatchFuzzy {
+		// Compare the distinct normalized names within each category
+		byCategory := map[string][]int{}
+		for _, i := range byKey {
+			byCategory[apps[i].Category] = append(byCategory[apps[i].Category], i)
+		}
+		for _, members := range byCategory {
+			for x := 0; x < len(members); x++ {
+				a := utils.NormalizeName(apps[members[x]].App)
+				for y := x + 1; y < len(members); y++ {
+					b := utils.NormalizeName(apps[members[y]].App)
+					if !similarLength(a, b) {
+						continue
+					}
+					if utils.Similarity(a, b) >= DuplicateSimilarityThreshold {
+						union(members[x], members[y])
+					}
+				}
+			}
+		}
+	}
+
+	grouped := map[int][]App{}
+	for i, app := range apps {
+		root := find(i)
+		grouped[root] = append(grouped[root], app)
+	}
+
+	groups := []DuplicateGroup{}
+	for _, members := range grouped {
+		if len(members) < 2 {
+			continue
+		}
+		sort.SliceStable(members, func(i, j int) bool {
+			if members[i].Reviews != members[j].Reviews {
+				return members[i].Reviews > members[j].Reviews
+			}
+			return members[i].AppId < members[j].AppId
+		})
+		groups = append(groups, DuplicateGroup{
+			Match:      match,
+			Canonical:  members[0],
+			Duplicates: members[1:],
+		})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Canonical.AppId < groups[j].Canonical.AppId
+	})
+	return groups, nil
+}
+
+// similarLength reports whether two names are close enough in length to
+// possibly reach DuplicateSimilarityThreshold.
+func similarLength(a, b string) bool {
+	la, lb := len([]rune(a)), len([]rune(b))
+	longest := max(la, lb)
+	if longest == 0 {
+		return true
+	}
+	diff := la - lb
+	if diff < 0 {
+		diff = -diff
+	}
+	return 1-float64(diff)/float64(longest) >= DuplicateSimilarityThreshold
+}
+
+// MergeApps keeps the canonical app, re-points reviews of the duplicates to
+// it and deletes the duplicates, all inside a single transaction.
+func (model *AppModel) MergeApps(ctx context.Context, canonicalID int, duplicateIDs []int) (MergeResult, error) {
+	ctx = database.WithOperation(ctx, "apps.merge")
+	result := MergeResult{}
+	ids := lo.Uniq(duplicateIDs)
+	if lo.Contains(ids, canonicalID) {
+		return result, ErrInvalidMerge
+	}
+
+	err := model.db.WithTx(func(tx *goqu.TxDatabase) error {
+		found, err := tx.From(AppTable).Where(goqu.Ex{"id": canonicalID}).ScanStructContext(ctx, &result.Canonical)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return sql.ErrNoRows
+		}
+
+		var duplicates []App
+		if err := tx.From(AppTable).Where(goqu.Ex{"id": ids}).ScanStructsContext(ctx, &duplicates); err != nil {
+			return err
+		}
+		if len(duplicates) != len(ids) {
+			return ErrMergeAppNotFound
+		}
+
+		// Reviews reference apps by name, so only names that no remaining app
+		// uses any more can be re-pointed safely.
+		names := lo.Uniq(lo.FilterMap(duplicates, func(app App, _ int) (string, bool) {
+			return app.App, app.App != result.Canonical.App
+		}))
+		if len(names) > 0 {
+			var stillUsed []string
+			err := tx.From(AppTable).Select("app").Where(
+				goqu.Ex{"app": names},
+				goqu.C("id").NotIn(ids),
+			).ScanValsContext(ctx, &stillUsed)
+			if err != nil {
+				return err
+			}
+			names, _ = lo.Difference(names, stillUsed)
+		}
+		if len(names) > 0 {
+			res, err := tx.Update(ReviewTable).
+				Set(goqu.Record{"app": result.Canonical.App}).
+				Where(goqu.Ex{"app": names}).
+				Executor().ExecContext(ctx)
+			if err != nil {
+				return err
+			}
+			if result.ReviewsRepointed, err = res.RowsAffected(); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.Delete(AppTable).Where(goqu.Ex{"id": ids}).Executor().ExecContext(ctx); err != nil {
+			return err
+		}
+		result.DeletedIDs = ids
+		return nil
+	})
+	if err != nil {
+		return MergeResult{}, err
+	}
+	return result, nil
+}
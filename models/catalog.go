@@ -0,0 +1,78 @@
+package models
+
+import (
+	"context"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/database"
+	"github.com/doug-martin/goqu/v9"
+)
+
+// CatalogStats summarizes the apps and reviews in the catalog
+type CatalogStats struct {
+	Apps                    int64
+	Reviews                 int64
+	ReviewsBySentiment      map[string]int64
+	AverageRatingByCategory map[string]float64
+}
+
+// CatalogModel implements catalog wide statistics
+type CatalogModel struct {
+	db *goqu.Database
+}
+
+// InitCatalogModel Init model
+func InitCatalogModel(goqu *goqu.Database) (CatalogModel, error) {
+	return CatalogModel{
+		db: goqu,
+	}, nil
+}
+
+// GetCatalogStats counts apps and reviews, reviews per sentiment and the
+// average rating per category.
+func (model *CatalogModel) GetCatalogStats(ctx context.Context) (CatalogStats, error) {
+	ctx = database.WithOperation(ctx, "catalog.stats")
+	stats := CatalogStats{
+		ReviewsBySentiment:      map[string]int64{},
+		AverageRatingByCategory: map[string]float64{},
+	}
+
+	var err error
+	if stats.Apps, err = model.db.From(AppTable).CountContext(ctx); err != nil {
+		return stats, err
+	}
+	if stats.Reviews, err = model.db.From(ReviewTable).CountContext(ctx); err != nil {
+		return stats, err
+	}
+
+	var sentiments []struct {
+		Sentiment string `db:"sentiment"`
+		Count     int64  `db:"count"`
+	}
+	err = model.db.From(ReviewTable).
+		Select("sentiment", goqu.COUNT("*").As("count")).
+		GroupBy("sentiment").
+		ScanStructsContext(ctx, &sentiments)
+	if err != nil {
+		return stats, err
+	}
+	for _, row := range sentiments {
+		stats.ReviewsBySentiment[row.Sentiment] = row.Count
+	}
+
+	var categories []struct {
+		Category      string  `db:"category"`
+		AverageRating float64 `db:"average_rating"`
+	}
+	err = model.db.From(AppTable).
+		Select("category", goqu.COALESCE(goqu.AVG("rating"), 0).As("average_rating")).
+		GroupBy("category").
+		ScanStructsContext(ctx, &categories)
+	if err != nil {
+		return stats, err
+	}
+	for _, row := range categories {
+		stats.AverageRatingByCategory[row.Category] = row.AverageRating
+	}
+
+	return stats, nil
+}
@@ -1,11 +1,13 @@
 package models
 
 import (
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/database"
 	"github.com/doug-martin/goqu/v9"
 )
 
@@ -17,9 +19,9 @@ type Review struct {
 	ReviewID              int             `json:"id" db:"id"`
 	App                   string          `json:"app" db:"app" validate:"required"`
 	TranslatedReview      string          `json:"translated_review" db:"translated_review" validate:"required"`
-	Sentiment             string          `json:"sentiment" db:"sentiment" validate:"required"`
-	SentimentPolarity     NullableFloat64 `json:"sentiment_polarity" db:"sentiment_polarity"`
-	SentimentSubjectivity NullableFloat64 `json:"sentiment_subjectivity" db:"sentiment_subjectivity"`
+	Sentiment             string          `json:"sentiment" db:"sentiment" validate:"required,oneof=Positive Neutral Negative"`
+	SentimentPolarity     NullableFloat64 `json:"sentiment_polarity" db:"sentiment_polarity" validate:"omitempty,gte=-1,lte=1"`
+	SentimentSubjectivity NullableFloat64 `json:"sentiment_subjectivity" db:"sentiment_subjectivity" validate:"omitempty,gte=0,lte=1"`
 }
 
 // ReviewModel implements review related database operations
@@ -96,7 +98,8 @@ func InitReviewModel(goqu *goqu.Database) (ReviewModel, error) {
 }
 
 // GetReviews lists all reviews.
-func (model *ReviewModel) GetReviews(limit, offset int) ([]Review, error) {
+func (model *ReviewModel) GetReviews(ctx context.Context, limit, offset int) ([]Review, error) {
+	ctx = database.WithOperation(ctx, "reviews.list")
 	var reviews []Review
 	query := model.db.From(ReviewTable)
 
@@ -107,18 +110,19 @@ func (model *ReviewModel) GetReviews(limit, offset int) ([]Review, error) {
 		query = query.Offset(uint(offset))
 	}
 
-	if err := query.ScanStructs(&reviews); err != nil {
+	if err := query.ScanStructsContext(ctx, &reviews); err != nil {
 		return nil, err
 	}
 	return reviews, nil
 }
 
 // GetById gets a review by its ID.
-func (model *ReviewModel) GetReviewById(id int) (Review, error) {
+func (model *ReviewModel) GetReviewById(ctx context.Context, id int) (Review, error) {
+	ctx = database.WithOperation(ctx, "reviews.get")
 	review := Review{}
 	found, err := model.db.From(ReviewTable).Where(goqu.Ex{
 		"id": id,
-	}).ScanStruct(&review)
+	}).ScanStructContext(ctx, &review)
 
 	if err != nil {
 		return review, err
@@ -133,7 +137,8 @@ func (model *ReviewModel) GetReviewById(id int) (Review, error) {
 
 // InsertReviews inserts a new review into the database.
 // InsertReviews inserts a new review into the database.
-func (model *ReviewModel) InsertReviews(review Review) (Review, error) {
+func (model *ReviewModel) InsertReviews(ctx context.Context, review Review) (Review, error) {
+	ctx = database.WithOperation(ctx, "reviews.insert")
 	var insertedID int64
 
 	_, err := model.db.Insert(ReviewTable).
@@ -146,7 +151,7 @@ func (model *ReviewModel) InsertReviews(review Review) (Review, error) {
 		}).
 		Returning("id"). // Get the ID of the inserted row
 		Executor().
-		ScanVal(&insertedID)
+		ScanValContext(ctx, &insertedID)
 
 	if err != nil {
 		return Review{}, err
@@ -157,10 +162,11 @@ func (model *ReviewModel) InsertReviews(review Review) (Review, error) {
 }
 
 // DeleteApp deletes a review by its ID.
-func (model *ReviewModel) DeleteApp(id int) error {
+func (model *ReviewModel) DeleteApp(ctx context.Context, id int) error {
+	ctx = database.WithOperation(ctx, "reviews.delete")
 	result, err := model.db.Delete(ReviewTable).Where(goqu.Ex{
 		"id": id,
-	}).Executor().Exec()
+	}).Executor().ExecContext(ctx)
 	if err != nil {
 		return err
 	}
@@ -175,14 +181,15 @@ func (model *ReviewModel) DeleteApp(id int) error {
 }
 
 // UpdateReview updates an existing review in the database.
-func (model *ReviewModel) UpdateReview(id int, review Review) (Review, error) {
+func (model *ReviewModel) UpdateReview(ctx context.Context, id int, review Review) (Review, error) {
+	ctx = database.WithOperation(ctx, "reviews.update")
 	result, err := model.db.Update(ReviewTable).Set(goqu.Record{
 		"app":                    review.App,
 		"translated_review":      review.TranslatedReview,
 		"sentiment":              review.Sentiment,
 		"sentiment_polarity":     review.SentimentPolarity,
 		"sentiment_subjectivity": review.SentimentSubjectivity,
-	}).Where(goqu.Ex{"id": id}).Executor().Exec()
+	}).Where(goqu.Ex{"id": id}).Executor().ExecContext(ctx)
 	if err != nil {
 		return Review{}, err
 	}
@@ -0,0 +1,248 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+)
+
+// Statuses of checks and reports
+const (
+	StatusOK = "ok"
+	// StatusDegraded means only non-critical checks failed
+	StatusDegraded = "degraded"
+	StatusFail     = "fail"
+)
+
+// Checker checks whether a dependency is usable. Check should return once ctx
+// is done.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapts a function to a Checker
+type CheckerFunc func(ctx context.Context) error
+
+// Check calls fn
+func (fn CheckerFunc) Check(ctx context.Context) error {
+	return fn(ctx)
+}
+
+// Check is a dependency registered by a subsystem
+type Check struct {
+	Name    string
+	Checker Checker
+	// Critical checks make the process unready when they fail, other checks
+	// only degrade it
+	Critical bool
+	// Timeout overrides the timeout of the registry
+	Timeout time.Duration
+}
+
+// Result is the outcome of a check. Latency, CheckedAt and the last error are
+// only reported in verbose mode.
+type Result struct {
+	Status      string     `json:"status"`
+	Critical    bool       `json:"critical"`
+	Latency     string     `json:"latency,omitempty"`
+	CheckedAt   *time.Time `json:"checked_at,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+}
+
+// Report is the outcome of all checks
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks,omitempty"`
+}
+
+// Brief returns the report without the verbose details
+func (report Report) Brief() Report {
+	brief := Report{Status: report.Status, Checks: make(map[string]Result, len(report.Checks))}
+	for name, result := range report.Checks {
+		brief.Checks[name] = Result{Status: result.Status, Critical: result.Critical}
+	}
+	return brief
+}
+
+// entry is a registered check with its latest result
+type entry struct {
+	Check
+	status      string
+	latency     time.Duration
+	checkedAt   time.Time
+	lastError   string
+	lastErrorAt time.Time
+}
+
+// Registry runs the checks registered by subsystems
+type Registry struct {
+	timeout  time.Duration
+	cacheTTL time.Duration
+
+	mu      sync.Mutex
+	entries []*entry
+	// runMu lets one Run check dependencies at a time, concurrent probes wait
+	// and share its results instead of checking again
+	runMu sync.Mutex
+}
+
+// NewRegistry returns an empty registry with the timeout and cache TTL of cfg
+func NewRegistry(cfg config.HealthConfig) *Registry {
+	return &Registry{timeout: cfg.CheckTimeout, cacheTTL: cfg.CacheTTL}
+}
+
+// Register adds check, names must be unique
+func (r *Registry) Register(check Check) error {
+	if check.Name == "" || check.Checker == nil {
+		return errors.New("health check needs a name and a checker")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if slices.ContainsFunc(r.entries, func(e *entry) bool { return e.Name == check.Name }) {
+		return fmt.Errorf("health check %q is already registered", check.Name)
+	}
+	r.entries = append(r.entries, &entry{Check: check})
+	return nil
+}
+
+// Run runs all checks in parallel, each bounded by its timeout, and reports
+// their results. Results younger than the cache TTL are reused.
+func (r *Registry) Run(ctx context.Context) Report {
+	r.mu.Lock()
+	entries := slices.Clone(r.entries)
+	r.mu.Unlock()
+	return r.runEntries(ctx, entries)
+}
+
+// RunCheck runs the check registered as name like Run does, and reports its
+// result only. ok is false when no check is registered as name.
+func (r *Registry) RunCheck(ctx context.Context, name string) (report Report, ok bool) {
+	r.mu.Lock()
+	i := slices.IndexFunc(r.entries, func(e *entry) bool { return e.Name == name })
+	var entries []*entry
+	if i >= 0 {
+		entries = []*entry{r.entries[i]}
+	}
+	r.mu.Unlock()
+
+	if entries == nil {
+		return Report{}, false
+	}
+	return r.runEntries(ctx, entries), true
+}
+
+// runEntries checks the stale entries and reports the results of entries.
+// Each check runs in one plain goroutine, outside of the routines that
+// shutdown waits for, and a checker ignoring its context is abandoned once
+// its timeout passed.
+func (r *Registry) runEntries(ctx context.Context, entries []*entry) Report {
+	r.runMu.Lock()
+	defer r.runMu.Unlock()
+
+	var checks []*running
+	for _, e := range entries {
+		if !e.checkedAt.IsZero() && time.Since(e.checkedAt) < r.cacheTTL {
+			continue
+		}
+		checks = append(checks, r.start(ctx, e))
+	}
+	for _, check := range checks {
+		check.wait()
+	}
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(entries))}
+	for _, e := range entries {
+		report.Checks[e.Name] = e.result()
+		if e.status == StatusOK {
+			continue
+		}
+		if e.Critical {
+			report.Status = StatusFail
+		} else if report.Status == StatusOK {
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+// running is a check started by runEntries
+type running struct {
+	entry  *entry
+	ctx    context.Context
+	cancel context.CancelFunc
+	start  time.Time
+	done   chan outcome
+}
+
+// outcome is the error of a checker and when it returned
+type outcome struct {
+	err error
+	at  time.Time
+}
+
+// start runs the checker of e in a goroutine bounded by the timeout of e
+func (r *Registry) start(ctx context.Context, e *entry) *running {
+	timeout := r.timeout
+	if e.Timeout > 0 {
+		timeout = e.Timeout
+	}
+	check := &running{entry: e, start: time.Now(), done: make(chan outcome, 1)}
+	check.ctx, check.cancel = context.WithTimeout(ctx, timeout)
+
+	go func() {
+		// A panicking checker fails its check rather than the process
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				check.done <- outcome{err: fmt.Errorf("panic: %v", recovered), at: time.Now()}
+			}
+		}()
+		err := e.Checker.Check(check.ctx)
+		check.done <- outcome{err: err, at: time.Now()}
+	}()
+	return check
+}
+
+// wait records the outcome of the check, or a timeout once its context is done
+func (check *running) wait() {
+	defer check.cancel()
+
+	var result outcome
+	select {
+	case result = <-check.done:
+	case <-check.ctx.Done():
+		result = outcome{err: check.ctx.Err(), at: time.Now()}
+	}
+
+	e := check.entry
+	e.checkedAt = result.at
+	e.latency = result.at.Sub(check.start)
+	e.status = StatusOK
+	if result.err != nil {
+		e.status = StatusFail
+		e.lastError = result.err.Error()
+		e.lastErrorAt = result.at
+	}
+}
+
+func (e *entry) result() Result {
+	checkedAt := e.checkedAt
+	result := Result{
+		Status:    e.status,
+		Critical:  e.Critical,
+		Latency:   e.latency.String(),
+		CheckedAt: &checkedAt,
+	}
+	if e.lastError != "" {
+		lastErrorAt := e.lastErrorAt
+		result.LastError = e.lastError
+		result.LastErrorAt = &lastErrorAt
+	}
+	return result
+}
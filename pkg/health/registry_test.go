@@ -0,0 +1,174 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/health"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/routinewrapper"
+	"github.com/stretchr/testify/assert"
+)
+
+// switchable is a checker failing while err is set, counting its calls
+type switchable struct {
+	mu    sync.Mutex
+	err   error
+	calls atomic.Int32
+}
+
+func (s *switchable) Check(ctx context.Context) error {
+	s.calls.Add(1)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *switchable) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+func sleeper(d time.Duration) health.CheckerFunc {
+	return func(ctx context.Context) error {
+		select {
+		case <-time.After(d):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// TestRegistry tests running registered checks
+func TestRegistry(t *testing.T) {
+	// Test case 1: Only failing critical checks fail the report
+	t.Run("critical and non-critical checks", func(t *testing.T) {
+		registry := health.NewRegistry(config.HealthConfig{CheckTimeout: time.Second})
+		critical, optional := &switchable{}, &switchable{}
+		assert.Nil(t, registry.Register(health.Check{Name: "database", Checker: critical, Critical: true}))
+		assert.Nil(t, registry.Register(health.Check{Name: "kratos", Checker: optional}))
+
+		assert.Equal(t, health.StatusOK, registry.Run(context.Background()).Status)
+
+		optional.fail(errors.New("kratos unavailable"))
+		report := registry.Run(context.Background())
+		assert.Equal(t, health.StatusDegraded, report.Status)
+		assert.Equal(t, health.StatusFail, report.Checks["kratos"].Status)
+		assert.Equal(t, "kratos unavailable", report.Checks["kratos"].LastError)
+
+		critical.fail(errors.New("database unavailable"))
+		assert.Equal(t, health.StatusFail, registry.Run(context.Background()).Status)
+	})
+
+	// Test case 2: Checks run in parallel, each within its timeout
+	t.Run("parallel checks with timeouts", func(t *testing.T) {
+		registry := health.NewRegistry(config.HealthConfig{CheckTimeout: 500 * time.Millisecond})
+		assert.Nil(t, registry.Register(health.Check{Name: "first", Checker: sleeper(100 * time.Millisecond), Critical: true}))
+		assert.Nil(t, registry.Register(health.Check{Name: "second", Checker: sleeper(100 * time.Millisecond), Critical: true}))
+		assert.Nil(t, registry.Register(health.Check{Name: "slow", Checker: sleeper(time.Hour), Timeout: 50 * time.Millisecond}))
+		// A checker ignoring its context is abandoned
+		assert.Nil(t, registry.Register(health.Check{Name: "stuck", Checker: health.CheckerFunc(func(context.Context) error {
+			time.Sleep(time.Second)
+			return nil
+		})}))
+
+		start := time.Now()
+		report := registry.Run(context.Background())
+		assert.Less(t, time.Since(start), 900*time.Millisecond)
+		assert.Equal(t, health.StatusDegraded, report.Status)
+		assert.Equal(t, health.StatusOK, report.Checks["first"].Status)
+		assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["slow"].LastError)
+		assert.Equal(t, health.StatusFail, report.Checks["stuck"].Status)
+	})
+
+	// Test case 3: Results are cached for the cache TTL
+	t.Run("cached results", func(t *testing.T) {
+		registry := health.NewRegistry(config.HealthConfig{CheckTimeout: time.Second, CacheTTL: 50 * time.Millisecond})
+		checker := &switchable{}
+		assert.Nil(t, registry.Register(health.Check{Name: "database", Checker: checker, Critical: true}))
+
+		registry.Run(context.Background())
+		registry.Run(context.Background())
+		assert.Equal(t, int32(1), checker.calls.Load())
+
+		time.Sleep(60 * time.Millisecond)
+		registry.Run(context.Background())
+		assert.Equal(t, int32(2), checker.calls.Load())
+	})
+
+	// Test case 4: The last error is kept after the check recovers
+	t.Run("last error", func(t *testing.T) {
+		registry := health.NewRegistry(config.HealthConfig{CheckTimeout: time.Second})
+		checker := &switchable{}
+		assert.Nil(t, registry.Register(health.Check{Name: "database", Checker: checker, Critical: true}))
+
+		checker.fail(errors.New("connection refused"))
+		registry.Run(context.Background())
+		checker.fail(nil)
+		report := registry.Run(context.Background())
+
+		result := report.Checks["database"]
+		assert.Equal(t, health.StatusOK, result.Status)
+		assert.Equal(t, "connection refused", result.LastError)
+		assert.NotNil(t, result.LastErrorAt)
+		assert.NotEmpty(t, result.Latency)
+
+		brief := report.Brief().Checks["database"]
+		assert.Equal(t, health.Result{Status: health.StatusOK, Critical: true}, brief)
+	})
+
+	// Test case 5: Names are unique
+	t.Run("duplicate name", func(t *testing.T) {
+		registry := health.NewRegistry(config.HealthConfig{CheckTimeout: time.Second})
+		assert.Nil(t, registry.Register(health.Check{Name: "database", Checker: &switchable{}}))
+		assert.NotNil(t, registry.Register(health.Check{Name: "database", Checker: &switchable{}}))
+		assert.NotNil(t, registry.Register(health.Check{Name: "cache"}))
+	})
+
+	// Test case 6: A single check can be run on its own
+	t.Run("run one check", func(t *testing.T) {
+		registry := health.NewRegistry(config.HealthConfig{CheckTimeout: time.Second})
+		database, kratos := &switchable{}, &switchable{}
+		assert.Nil(t, registry.Register(health.Check{Name: "database", Checker: database, Critical: true}))
+		assert.Nil(t, registry.Register(health.Check{Name: "kratos", Checker: kratos}))
+
+		database.fail(errors.New("connection refused"))
+		report, ok := registry.RunCheck(context.Background(), "database")
+		assert.True(t, ok)
+		assert.Equal(t, health.StatusFail, report.Status)
+		assert.Len(t, report.Checks, 1)
+		assert.Equal(t, "connection refused", report.Checks["database"].LastError)
+		assert.Equal(t, int32(0), kratos.calls.Load())
+
+		_, ok = registry.RunCheck(context.Background(), "cache")
+		assert.False(t, ok)
+	})
+
+	// Test case 7: Shutdown doesn't wait for a running check
+	t.Run("checks outside of shutdown routines", func(t *testing.T) {
+		registry := health.NewRegistry(config.HealthConfig{CheckTimeout: time.Second})
+		started, release := make(chan struct{}), make(chan struct{})
+		assert.Nil(t, registry.Register(health.Check{Name: "slow", Checker: health.CheckerFunc(func(ctx context.Context) error {
+			close(started)
+			<-release
+			return nil
+		})}))
+
+		ran := make(chan health.Report)
+		go func() { ran <- registry.Run(context.Background()) }()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		assert.Nil(t, routinewrapper.Wait(ctx))
+
+		close(release)
+		assert.Equal(t, health.StatusOK, (<-ran).Status)
+	})
+}
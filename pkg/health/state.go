@@ -0,0 +1,31 @@
+package health
+
+import "sync/atomic"
+
+// State tracks the lifecycle of the process for the probes. The zero value is
+// a process that hasn't started yet.
+type State struct {
+	started  atomic.Bool
+	draining atomic.Bool
+}
+
+// MarkStarted records that the process started, it stays started afterwards
+func (s *State) MarkStarted() {
+	s.started.Store(true)
+}
+
+// Started reports whether the process started
+func (s *State) Started() bool {
+	return s.started.Load()
+}
+
+// Drain records that the process is shutting down, so it stops being ready
+// while in-flight requests finish
+func (s *State) Drain() {
+	s.draining.Store(true)
+}
+
+// Draining reports whether the process is shutting down
+func (s *State) Draining() bool {
+	return s.draining.Load()
+}
@@ -0,0 +1,364 @@
+package jwks
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/routinewrapper"
+)
+
+// ErrKeyNotFound is returned when no key of the set matches a token
+var ErrKeyNotFound = errors.New("no matching key in jwks")
+
+// errLoadPanicked is kept as the load error when the source panics
+var errLoadPanicked = errors.New("loading jwks: source panicked")
+
+// maxJWKSSize bounds how much of a JWKS response is read
+const maxJWKSSize = 1 << 20
+
+// Source loads a JWKS document
+type Source interface {
+	Load(ctx context.Context) ([]byte, error)
+}
+
+// FileSource loads the JWKS from a local file
+type FileSource string
+
+// Load implements Source
+func (path FileSource) Load(ctx context.Context) ([]byte, error) {
+	return os.ReadFile(string(path))
+}
+
+// URLSource fetches the JWKS over HTTP
+type URLSource struct {
+	URL    string
+	Client *http.Client
+}
+
+// Load implements Source
+func (source URLSource) Load(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	client := source.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching jwks from %s: unexpected status %s", source.URL, res.Status)
+	}
+	return io.ReadAll(io.LimitReader(res.Body, maxJWKSSize))
+}
+
+// Key is a verification key of a JWKS
+type Key struct {
+	ID string
+	// Algorithm is the alg the key is restricted to, empty when unrestricted
+	Algorithm string
+	// Public is a *rsa.PublicKey, *ecdsa.PublicKey or []byte HMAC secret
+	Public any
+}
+
+// KeySet caches the keys of a JWKS. Keys are loaded again once the refresh
+// interval has passed, and early when a token names an unknown key ID so
+// rotated keys are picked up, at most once per minRefreshInterval. When the
+// source fails the cached keys keep being used.
+//
+// Only one load runs at a time and the lock isn't held while it runs, cached
+// keys are served meanwhile and lookups of keys that aren't cached wait for it.
+type KeySet struct {
+	source             Source
+	refreshInterval    time.Duration
+	minRefreshInterval time.Duration
+
+	mu          sync.Mutex
+	keys        map[string]Key
+	loadedAt    time.Time
+	attemptedAt time.Time
+	// err is the error of the last load, returned while no keys were loaded
+	err error
+	// refreshing is closed when the running load ends, nil while none runs
+	refreshing chan struct{}
+}
+
+// DefaultMinRefreshInterval limits how often unknown key IDs and failing
+// sources trigger a reload
+const DefaultMinRefreshInterval = 30 * time.Second
+
+// NewKeySet returns a key set loading keys from source, the first load
+// happens when a key is first looked up
+func NewKeySet(source Source, refreshInterval time.Duration) *KeySet {
+	return &KeySet{
+		source:             source,
+		refreshInterval:    refreshInterval,
+		minRefreshInterval: min(DefaultMinRefreshInterval, refreshInterval),
+	}
+}
+
+// Key returns the key with kid. An empty kid matches the only key of a set
+// holding a single key.
+func (ks *KeySet) Key(ctx context.Context, kid string) (Key, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	_, known := ks.lookup(kid)
+	expired := ks.keys == nil || time.Since(ks.loadedAt) >= ks.refreshInterval
+	if (expired || !known) && time.Since(ks.attemptedAt) >= ks.minRefreshInterval {
+		ks.startRefresh(ctx)
+	}
+	if !known && ks.refreshing != nil {
+		if err := ks.wait(ctx); err != nil {
+			return Key{}, err
+		}
+	}
+	if ks.keys == nil {
+		return Key{}, ks.err
+	}
+
+	key, ok := ks.lookup(kid)
+	if !ok {
+		return Key{}, fmt.Errorf("%w: kid %q", ErrKeyNotFound, kid)
+	}
+	return key, nil
+}
+
+// Refresh loads the keys from the source now, or waits for the running load
+func (ks *KeySet) Refresh(ctx context.Context) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.startRefresh(ctx)
+	if err := ks.wait(ctx); err != nil {
+		return err
+	}
+	return ks.err
+}
+
+// Check reloads expired keys like a lookup would, and fails while no keys are
+// loaded or the cached keys are kept because the source fails, since tokens
+// signed by rotated keys can't be verified then
+func (ks *KeySet) Check(ctx context.Context) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	expired := ks.keys == nil || time.Since(ks.loadedAt) >= ks.refreshInterval
+	if expired && time.Since(ks.attemptedAt) >= ks.minRefreshInterval {
+		ks.startRefresh(ctx)
+	}
+	if err := ks.wait(ctx); err != nil {
+		return err
+	}
+	if ks.err != nil && ks.keys != nil {
+		return fmt.Errorf("using keys loaded at %s: %w", ks.loadedAt.Format(time.RFC3339), ks.err)
+	}
+	return ks.err
+}
+
+// startRefresh starts loading the keys unless a load is already running. The
+// load isn't canceled with ctx, since other lookups may be waiting for it.
+// ks.mu must be held.
+func (ks *KeySet) startRefresh(ctx context.Context) {
+	if ks.refreshing != nil {
+		return
+	}
+	done := make(chan struct{})
+	ks.refreshing = done
+	ks.attemptedAt = time.Now()
+	attemptedAt := ks.attemptedAt
+
+	routinewrapper.Go(context.WithoutCancel(ctx), "jwks refresh", func(ctx context.Context) error {
+		// Replaced by the result of load, unless the source panics
+		var keys map[string]Key
+		err := errLoadPanicked
+		defer func() {
+			ks.mu.Lock()
+			ks.err = err
+			if err == nil {
+				ks.keys = keys
+				ks.loadedAt = attemptedAt
+			}
+			ks.refreshing = nil
+			ks.mu.Unlock()
+			close(done)
+		}()
+		keys, err = ks.load(ctx)
+		return nil
+	})
+}
+
+// wait releases ks.mu until the running load, if any, ended or ctx is done.
+// ks.mu must be held.
+func (ks *KeySet) wait(ctx context.Context) error {
+	done := ks.refreshing
+	if done == nil {
+		return nil
+	}
+	ks.mu.Unlock()
+	defer ks.mu.Lock()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (ks *KeySet) load(ctx context.Context) (map[string]Key, error) {
+	data, err := ks.source.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading jwks: %w", err)
+	}
+	return Parse(data)
+}
+
+func (ks *KeySet) lookup(kid string) (Key, bool) {
+	if kid == "" && len(ks.keys) == 1 {
+		for _, key := range ks.keys {
+			return key, true
+		}
+	}
+	key, ok := ks.keys[kid]
+	return key, ok
+}
+
+// jwk is a JSON Web Key as defined by RFC 7517, only the members needed to
+// verify signatures are decoded
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	// Symmetric
+	K string `json:"k"`
+}
+
+// Parse decodes a JWKS document into its signature keys by key ID. Keys of
+// unsupported types and encryption keys are skipped.
+func Parse(data []byte) (map[string]Key, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("decoding jwks: %w", err)
+	}
+
+	keys := make(map[string]Key, len(set.Keys))
+	for _, raw := range set.Keys {
+		if raw.Use != "" && raw.Use != "sig" {
+			continue
+		}
+		public, err := raw.publicKey()
+		if errors.Is(err, errUnsupportedKey) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decoding jwk %q: %w", raw.Kid, err)
+		}
+		keys[raw.Kid] = Key{ID: raw.Kid, Algorithm: raw.Alg, Public: public}
+	}
+	return keys, nil
+}
+
+var errUnsupportedKey = errors.New("unsupported key type")
+
+func (raw jwk) publicKey() (any, error) {
+	switch raw.Kty {
+	case "RSA":
+		n, err := decodeBigInt(raw.N)
+		if err != nil {
+			return nil, fmt.Errorf("n: %w", err)
+		}
+		e, err := decodeBigInt(raw.E)
+		if err != nil {
+			return nil, fmt.Errorf("e: %w", err)
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("e: exponent too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		return raw.ecdsaKey()
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(raw.K)
+		if err != nil {
+			return nil, fmt.Errorf("k: %w", err)
+		}
+		return secret, nil
+	default:
+		return nil, errUnsupportedKey
+	}
+}
+
+func (raw jwk) ecdsaKey() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	var ecdhCurve ecdh.Curve
+	switch raw.Crv {
+	case "P-256":
+		curve, ecdhCurve = elliptic.P256(), ecdh.P256()
+	case "P-384":
+		curve, ecdhCurve = elliptic.P384(), ecdh.P384()
+	case "P-521":
+		curve, ecdhCurve = elliptic.P521(), ecdh.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", raw.Crv)
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(raw.X)
+	if err != nil {
+		return nil, fmt.Errorf("x: %w", err)
+	}
+	y, err := base64.RawURLEncoding.DecodeString(raw.Y)
+	if err != nil {
+		return nil, fmt.Errorf("y: %w", err)
+	}
+	size := (curve.Params().BitSize + 7) / 8
+	if len(x) != size || len(y) != size {
+		return nil, errors.New("invalid coordinate length")
+	}
+
+	// crypto/ecdh rejects points that are not on the curve
+	point := append([]byte{4}, append(x, y...)...)
+	if _, err := ecdhCurve.NewPublicKey(point); err != nil {
+		return nil, err
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,288 @@
+package jwks_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/jwks"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func rsaJWK(kid string, key *rsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA", "kid": kid, "alg": "RS256", "use": "sig",
+		"n": encode(key.N.Bytes()),
+		"e": encode(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "EC", "kid": kid, "crv": "P-256",
+		"x": encode(key.X.FillBytes(make([]byte, 32))),
+		"y": encode(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func octJWK(kid string, secret []byte) map[string]string {
+	return map[string]string{"kty": "oct", "kid": kid, "alg": "HS256", "k": encode(secret)}
+}
+
+func jwksDocument(t *testing.T, keys ...map[string]string) []byte {
+	data, err := json.Marshal(map[string]any{"keys": keys})
+	assert.Nil(t, err)
+	return data
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key any, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	assert.Nil(t, err)
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub": "user-1",
+		"iss": "https://issuer.test",
+		"aud": "fiber-csv-app",
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"realm_access": map[string]any{
+			"roles": []string{"platform-editor", "viewer"},
+		},
+	}
+}
+
+func testConfig() config.JWTConfig {
+	return config.JWTConfig{
+		JWKSRefreshInterval: time.Minute,
+		Algorithms:          []string{"RS256", "ES256", "HS256"},
+		Issuer:              "https://issuer.test",
+		Audience:            "fiber-csv-app",
+		RolesClaim:          "realm_access.roles",
+		RoleMapping:         map[string]string{"platform-editor": "editor"},
+	}
+}
+
+// TestVerifier tests validating tokens against a JWKS file
+func TestVerifier(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	secret := []byte("a-locally-generated-hmac-secret!")
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	assert.Nil(t, os.WriteFile(path, jwksDocument(t, rsaJWK("rsa", rsaKey), ecJWK("ec", ecKey), octJWK("hmac", secret)), 0o600))
+
+	cfg := testConfig()
+	cfg.JWKSFile = path
+	verifier := jwks.New(cfg)
+
+	// Test case 1: Tokens signed with each supported algorithm
+	t.Run("verify supported algorithms", func(t *testing.T) {
+		tokens := map[string]string{
+			"RS256": sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, validClaims()),
+			"ES256": sign(t, jwt.SigningMethodES256, "ec", ecKey, validClaims()),
+			"HS256": sign(t, jwt.SigningMethodHS256, "hmac", secret, validClaims()),
+		}
+		for alg, token := range tokens {
+			identity, err := verifier.Verify(context.Background(), token)
+			assert.Nil(t, err, alg)
+			assert.Equal(t, "user-1", identity.Subject, alg)
+			assert.Equal(t, []string{"editor", "viewer"}, identity.Roles, alg)
+		}
+	})
+
+	// Test case 2: Expired token
+	t.Run("reject expired token", func(t *testing.T) {
+		claims := validClaims()
+		claims["exp"] = time.Now().Add(-time.Hour).Unix()
+
+		_, err := verifier.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims))
+		assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+	})
+
+	// Test case 3: Token from another issuer
+	t.Run("reject wrong issuer", func(t *testing.T) {
+		claims := validClaims()
+		claims["iss"] = "https://other.test"
+
+		_, err := verifier.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims))
+		assert.ErrorIs(t, err, jwt.ErrTokenInvalidIssuer)
+	})
+
+	// Test case 4: Token signed by a key outside the JWKS
+	t.Run("reject unknown signer", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.Nil(t, err)
+
+		_, err = verifier.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, "rsa", otherKey, validClaims()))
+		assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+	})
+
+	// Test case 5: Key restricted to HS256 used for another algorithm
+	t.Run("reject algorithm not matching key", func(t *testing.T) {
+		_, err := verifier.Verify(context.Background(), sign(t, jwt.SigningMethodHS384, "hmac", secret, validClaims()))
+		assert.NotNil(t, err)
+	})
+
+	// Test case 6: Algorithm not configured
+	t.Run("reject disallowed algorithm", func(t *testing.T) {
+		restricted := testConfig()
+		restricted.JWKSFile = path
+		restricted.Algorithms = []string{"RS256"}
+
+		_, err := jwks.New(restricted).Verify(context.Background(), sign(t, jwt.SigningMethodHS256, "hmac", secret, validClaims()))
+		assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+	})
+
+	// Test case 7: Token without subject
+	t.Run("reject missing subject", func(t *testing.T) {
+		claims := validClaims()
+		delete(claims, "sub")
+
+		_, err := verifier.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims))
+		assert.ErrorIs(t, err, jwt.ErrTokenInvalidClaims)
+	})
+}
+
+// TestKeySetRotation tests picking up rotated keys from a JWKS URL
+func TestKeySetRotation(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+
+	var document atomic.Value
+	document.Store(jwksDocument(t, rsaJWK("old", oldKey)))
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		_, _ = w.Write(document.Load().([]byte))
+	}))
+	defer server.Close()
+
+	cfg := testConfig()
+	cfg.JWKSURL = server.URL
+	cfg.JWKSRefreshInterval = 50 * time.Millisecond
+	verifier := jwks.New(cfg)
+
+	// Test case 1: Keys are cached between tokens
+	t.Run("cache keys", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			_, err := verifier.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, "old", oldKey, validClaims()))
+			assert.Nil(t, err)
+		}
+		assert.Equal(t, int32(1), fetches.Load())
+		assert.Nil(t, verifier.Check(context.Background()))
+	})
+
+	// Test case 2: Unknown key ID reloads the JWKS once the minimum interval passed
+	t.Run("pick up rotated key", func(t *testing.T) {
+		document.Store(jwksDocument(t, rsaJWK("new", newKey)))
+		time.Sleep(60 * time.Millisecond)
+
+		_, err := verifier.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, "new", newKey, validClaims()))
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), fetches.Load())
+
+		_, err = verifier.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, "old", oldKey, validClaims()))
+		assert.ErrorIs(t, err, jwks.ErrKeyNotFound)
+	})
+
+	// Test case 3: Cached keys are kept while the source fails
+	t.Run("keep keys when source fails", func(t *testing.T) {
+		document.Store([]byte("not json"))
+		time.Sleep(60 * time.Millisecond)
+
+		_, err := verifier.Verify(context.Background(), sign(t, jwt.SigningMethodRS256, "new", newKey, validClaims()))
+		assert.Nil(t, err)
+		// Health check reports the stale keys
+		assert.NotNil(t, verifier.Check(context.Background()))
+	})
+}
+
+// blockingSource counts loads and blocks them while gate is open
+type blockingSource struct {
+	loads    atomic.Int32
+	document atomic.Value
+	gate     atomic.Value
+}
+
+func (source *blockingSource) Load(ctx context.Context) ([]byte, error) {
+	source.loads.Add(1)
+	if gate, ok := source.gate.Load().(chan struct{}); ok {
+		<-gate
+	}
+	return source.document.Load().([]byte), nil
+}
+
+// TestKeySetRefreshInFlight tests serving cached keys while a single load runs
+func TestKeySetRefreshInFlight(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+
+	source := &blockingSource{}
+	source.document.Store(jwksDocument(t, rsaJWK("old", oldKey)))
+	keys := jwks.NewKeySet(source, 50*time.Millisecond)
+
+	_, err = keys.Key(context.Background(), "old")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), source.loads.Load())
+
+	gate := make(chan struct{})
+	source.gate.Store(gate)
+	source.document.Store(jwksDocument(t, rsaJWK("new", newKey)))
+	time.Sleep(60 * time.Millisecond)
+
+	// Test case 1: Expired keys are served while they are loaded again, by a single load
+	t.Run("serve cached keys during refresh", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			key, err := keys.Key(context.Background(), "old")
+			assert.Nil(t, err)
+			assert.Equal(t, "old", key.ID)
+		}
+		assert.Eventually(t, func() bool { return source.loads.Load() == 2 }, time.Second, time.Millisecond)
+
+		_, err := keys.Key(context.Background(), "old")
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), source.loads.Load())
+	})
+
+	// Test case 2: Lookups of keys that aren't cached wait for the running load
+	t.Run("unknown key waits for refresh", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := keys.Key(ctx, "new")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		close(gate)
+		key, err := keys.Key(context.Background(), "new")
+		assert.Nil(t, err)
+		assert.Equal(t, "new", key.ID)
+		assert.Equal(t, int32(2), source.loads.Load())
+	})
+}
@@ -0,0 +1,136 @@
+package jwks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// fetchTimeout bounds loading the JWKS from a URL
+const fetchTimeout = 10 * time.Second
+
+// Identity is the caller a valid token was issued to
+type Identity struct {
+	Subject string
+	Roles   []string
+}
+
+// Verifier validates JWTs against the keys of a KeySet
+type Verifier struct {
+	keys        *KeySet
+	parser      *jwt.Parser
+	rolesClaim  string
+	roleMapping map[string]string
+}
+
+// New returns a verifier using the JWKS configured by cfg, or nil when JWT
+// authentication is disabled
+func New(cfg config.JWTConfig) *Verifier {
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	var source Source = URLSource{URL: cfg.JWKSURL, Client: &http.Client{Timeout: fetchTimeout}}
+	if cfg.JWKSFile != "" {
+		source = FileSource(cfg.JWKSFile)
+	}
+	return NewVerifier(NewKeySet(source, cfg.JWKSRefreshInterval), cfg)
+}
+
+// NewVerifier returns a verifier checking tokens against keys and the claims
+// required by cfg
+func NewVerifier(keys *KeySet, cfg config.JWTConfig) *Verifier {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(cfg.Algorithms),
+		jwt.WithLeeway(cfg.Leeway),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+
+	return &Verifier{
+		keys:        keys,
+		parser:      jwt.NewParser(options...),
+		rolesClaim:  cfg.RolesClaim,
+		roleMapping: cfg.RoleMapping,
+	}
+}
+
+// Verify checks the signature and registered claims of token and returns the
+// identity it was issued to
+func (v *Verifier) Verify(ctx context.Context, token string) (Identity, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, err := v.keys.Key(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		// A key restricted to an algorithm must not verify tokens of another
+		if key.Algorithm != "" && key.Algorithm != t.Method.Alg() {
+			return nil, fmt.Errorf("key %q is for %s, token is signed with %s", key.ID, key.Algorithm, t.Method.Alg())
+		}
+		return key.Public, nil
+	})
+	if err != nil {
+		return Identity{}, err
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil {
+		return Identity{}, err
+	}
+	if subject == "" {
+		return Identity{}, fmt.Errorf("%w: missing sub", jwt.ErrTokenInvalidClaims)
+	}
+	return Identity{Subject: subject, Roles: v.roles(claims)}, nil
+}
+
+// Check reports whether the keys tokens are verified with are available
+func (v *Verifier) Check(ctx context.Context) error {
+	return v.keys.Check(ctx)
+}
+
+// roles reads the roles claim, following dots into nested objects. The claim
+// may be a list of strings or a space separated string like the OAuth scope
+// claim. Values are renamed through the role mapping.
+func (v *Verifier) roles(claims jwt.MapClaims) []string {
+	var value any = map[string]any(claims)
+	for _, part := range strings.Split(v.rolesClaim, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[part]
+	}
+
+	var names []string
+	switch value := value.(type) {
+	case string:
+		names = strings.Fields(value)
+	case []any:
+		for _, item := range value {
+			if name, ok := item.(string); ok {
+				names = append(names, name)
+			}
+		}
+	}
+
+	roles := make([]string, 0, len(names))
+	for _, name := range names {
+		if role, ok := v.roleMapping[name]; ok {
+			name = role
+		}
+		roles = append(roles, name)
+	}
+	return roles
+}
@@ -0,0 +1,211 @@
+package kratos
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+)
+
+// HeaderSessionToken carries session tokens of API clients, as in Kratos
+const HeaderSessionToken = "X-Session-Token"
+
+// SessionTokenPrefix starts every Kratos session token
+const SessionTokenPrefix = "ory_st_"
+
+// ErrUnauthenticated is returned when Kratos knows no active session for the
+// credentials
+var ErrUnauthenticated = errors.New("no active kratos session")
+
+// maxCacheEntries bounds the number of cached sessions
+const maxCacheEntries = 10000
+
+// maxResponseSize bounds how much of a whoami response is read
+const maxResponseSize = 1 << 20
+
+// Credentials identify a session, either by the browser cookie or by the
+// session token of an API client
+type Credentials struct {
+	Cookie string
+	Token  string
+}
+
+// Identity is the caller a session belongs to
+type Identity struct {
+	ID    string
+	Roles []string
+}
+
+// session is the subset of the whoami response that is used
+type session struct {
+	Active    bool       `json:"active"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	Identity  struct {
+		ID             string `json:"id"`
+		MetadataPublic struct {
+			// Roles are kept in the public metadata, which only admins can change
+			Roles []string `json:"roles"`
+		} `json:"metadata_public"`
+	} `json:"identity"`
+}
+
+type cacheEntry struct {
+	identity  Identity
+	expiresAt time.Time
+}
+
+// Client resolves sessions through the whoami endpoint of Kratos, caching
+// active sessions for a short time
+type Client struct {
+	whoamiURL     string
+	sessionCookie string
+	cacheTTL      time.Duration
+	httpClient    *http.Client
+
+	mu    sync.Mutex
+	cache map[[sha256.Size]byte]cacheEntry
+}
+
+// New returns a client for the Kratos configured by cfg, or nil when session
+// authentication is disabled
+func New(cfg config.KratosConfig) *Client {
+	if cfg.WhoamiURL == "" {
+		return nil
+	}
+	return &Client{
+		whoamiURL:     cfg.WhoamiURL,
+		sessionCookie: cfg.SessionCookie,
+		cacheTTL:      cfg.CacheTTL,
+		httpClient:    &http.Client{Timeout: cfg.Timeout},
+		cache:         map[[sha256.Size]byte]cacheEntry{},
+	}
+}
+
+// SessionCookie is the name of the cookie holding browser sessions
+func (client *Client) SessionCookie() string {
+	return client.sessionCookie
+}
+
+// Whoami returns the identity of the active session identified by credentials
+func (client *Client) Whoami(ctx context.Context, credentials Credentials) (Identity, error) {
+	if credentials.Cookie == "" && credentials.Token == "" {
+		return Identity{}, ErrUnauthenticated
+	}
+
+	// Credentials are only kept hashed in memory
+	key := sha256.Sum256([]byte(credentials.Cookie + "\x00" + credentials.Token))
+	if identity, ok := client.cached(key); ok {
+		return identity, nil
+	}
+
+	current, err := client.whoami(ctx, credentials)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	identity := Identity{ID: current.Identity.ID, Roles: current.Identity.MetadataPublic.Roles}
+	expiresAt := time.Now().Add(client.cacheTTL)
+	if current.ExpiresAt != nil && current.ExpiresAt.Before(expiresAt) {
+		expiresAt = *current.ExpiresAt
+	}
+	client.store(key, cacheEntry{identity: identity, expiresAt: expiresAt})
+	return identity, nil
+}
+
+// Check reports whether Kratos answers whoami requests. Without credentials
+// a reachable Kratos answers 401.
+func (client *Client) Check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.whoamiURL, nil)
+	if err != nil {
+		return err
+	}
+	res, err := client.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling kratos whoami: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusUnauthorized && res.StatusCode != http.StatusOK {
+		return fmt.Errorf("calling kratos whoami: unexpected status %s", res.Status)
+	}
+	return nil
+}
+
+func (client *Client) whoami(ctx context.Context, credentials Credentials) (session, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.whoamiURL, nil)
+	if err != nil {
+		return session{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+	// Only the session cookie is forwarded, other cookies stay with this service
+	if credentials.Cookie != "" {
+		req.AddCookie(&http.Cookie{Name: client.sessionCookie, Value: credentials.Cookie})
+	}
+	if credentials.Token != "" {
+		req.Header.Set(HeaderSessionToken, credentials.Token)
+	}
+
+	res, err := client.httpClient.Do(req)
+	if err != nil {
+		return session{}, fmt.Errorf("calling kratos whoami: %w", err)
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return session{}, ErrUnauthenticated
+	default:
+		return session{}, fmt.Errorf("calling kratos whoami: unexpected status %s", res.Status)
+	}
+
+	var current session
+	if err := json.NewDecoder(io.LimitReader(res.Body, maxResponseSize)).Decode(&current); err != nil {
+		return session{}, fmt.Errorf("decoding kratos session: %w", err)
+	}
+	if !current.Active || current.Identity.ID == "" {
+		return session{}, ErrUnauthenticated
+	}
+	return current, nil
+}
+
+func (client *Client) cached(key [sha256.Size]byte) (Identity, bool) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	entry, ok := client.cache[key]
+	if !ok {
+		return Identity{}, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(client.cache, key)
+		return Identity{}, false
+	}
+	return entry.identity, true
+}
+
+func (client *Client) store(key [sha256.Size]byte, entry cacheEntry) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	if len(client.cache) >= maxCacheEntries {
+		now := time.Now()
+		for cachedKey, cachedEntry := range client.cache {
+			if now.After(cachedEntry.expiresAt) {
+				delete(client.cache, cachedKey)
+			}
+		}
+		// Still full of live sessions, start over rather than grow unbounded
+		if len(client.cache) >= maxCacheEntries {
+			clear(client.cache)
+		}
+	}
+	client.cache[key] = entry
+}
@@ -0,0 +1,163 @@
+package kratos_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/kratos"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	sessionCookie = "ory_kratos_session"
+	validCookie   = "valid-cookie"
+	validToken    = "ory_st_valid-token"
+	inactiveToken = "ory_st_inactive-token"
+)
+
+// fakeKratos serves /sessions/whoami like Kratos for a fixed set of sessions
+func fakeKratos(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path != "/sessions/whoami" {
+			http.NotFound(w, r)
+			return
+		}
+
+		active := false
+		if cookie, err := r.Cookie(sessionCookie); err == nil && cookie.Value == validCookie {
+			active = true
+		}
+		switch r.Header.Get(kratos.HeaderSessionToken) {
+		case validToken:
+			active = true
+		case inactiveToken:
+			w.Header().Set("Content-Type", "application/json")
+			assert.Nil(t, json.NewEncoder(w).Encode(map[string]any{"active": false}))
+			return
+		}
+		if !active {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		assert.Nil(t, json.NewEncoder(w).Encode(map[string]any{
+			"id":         "session-1",
+			"active":     true,
+			"expires_at": time.Now().Add(time.Hour),
+			"identity": map[string]any{
+				"id":              "identity-1",
+				"traits":          map[string]any{"email": "user@example.com"},
+				"metadata_public": map[string]any{"roles": []string{"editor"}},
+			},
+		}))
+	}))
+}
+
+// TestWhoami tests resolving sessions through a fake Kratos
+func TestWhoami(t *testing.T) {
+	var calls atomic.Int32
+	server := fakeKratos(t, &calls)
+	defer server.Close()
+
+	client := kratos.New(config.KratosConfig{
+		WhoamiURL:     server.URL + "/sessions/whoami",
+		SessionCookie: sessionCookie,
+		CacheTTL:      time.Minute,
+		Timeout:       time.Second,
+	})
+
+	// Test case 1: Browser session cookie
+	t.Run("session cookie", func(t *testing.T) {
+		identity, err := client.Whoami(context.Background(), kratos.Credentials{Cookie: validCookie})
+		assert.Nil(t, err)
+		assert.Equal(t, "identity-1", identity.ID)
+		assert.Equal(t, []string{"editor"}, identity.Roles)
+	})
+
+	// Test case 2: Resolved sessions are cached
+	t.Run("cached session", func(t *testing.T) {
+		before := calls.Load()
+		_, err := client.Whoami(context.Background(), kratos.Credentials{Cookie: validCookie})
+		assert.Nil(t, err)
+		assert.Equal(t, before, calls.Load())
+	})
+
+	// Test case 3: API client session token
+	t.Run("session token", func(t *testing.T) {
+		identity, err := client.Whoami(context.Background(), kratos.Credentials{Token: validToken})
+		assert.Nil(t, err)
+		assert.Equal(t, "identity-1", identity.ID)
+	})
+
+	// Test case 4: Unknown session
+	t.Run("unknown session", func(t *testing.T) {
+		_, err := client.Whoami(context.Background(), kratos.Credentials{Cookie: "expired-cookie"})
+		assert.ErrorIs(t, err, kratos.ErrUnauthenticated)
+	})
+
+	// Test case 5: Inactive session
+	t.Run("inactive session", func(t *testing.T) {
+		_, err := client.Whoami(context.Background(), kratos.Credentials{Token: inactiveToken})
+		assert.ErrorIs(t, err, kratos.ErrUnauthenticated)
+	})
+
+	// Test case 6: No credentials don't reach Kratos
+	t.Run("no credentials", func(t *testing.T) {
+		before := calls.Load()
+		_, err := client.Whoami(context.Background(), kratos.Credentials{})
+		assert.ErrorIs(t, err, kratos.ErrUnauthenticated)
+		assert.Equal(t, before, calls.Load())
+	})
+
+	// Test case 7: Kratos is unavailable
+	t.Run("kratos unavailable", func(t *testing.T) {
+		unavailable := kratos.New(config.KratosConfig{WhoamiURL: server.URL + "/unavailable", SessionCookie: sessionCookie, Timeout: time.Second})
+		_, err := unavailable.Whoami(context.Background(), kratos.Credentials{Cookie: validCookie})
+		assert.NotNil(t, err)
+		assert.NotErrorIs(t, err, kratos.ErrUnauthenticated)
+	})
+}
+
+// TestWhoamiCacheExpiry tests asking Kratos again once cached sessions expire
+func TestWhoamiCacheExpiry(t *testing.T) {
+	var calls atomic.Int32
+	server := fakeKratos(t, &calls)
+	defer server.Close()
+
+	client := kratos.New(config.KratosConfig{
+		WhoamiURL:     server.URL + "/sessions/whoami",
+		SessionCookie: sessionCookie,
+		CacheTTL:      20 * time.Millisecond,
+		Timeout:       time.Second,
+	})
+
+	_, err := client.Whoami(context.Background(), kratos.Credentials{Cookie: validCookie})
+	assert.Nil(t, err)
+	time.Sleep(30 * time.Millisecond)
+	_, err = client.Whoami(context.Background(), kratos.Credentials{Cookie: validCookie})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+// TestCheck tests the health check of the Kratos client
+func TestCheck(t *testing.T) {
+	var calls atomic.Int32
+	server := fakeKratos(t, &calls)
+	defer server.Close()
+
+	// Test case 1: Kratos rejecting the anonymous whoami is reachable
+	client := kratos.New(config.KratosConfig{WhoamiURL: server.URL + "/sessions/whoami", SessionCookie: sessionCookie, Timeout: time.Second})
+	assert.Nil(t, client.Check(context.Background()))
+
+	// Test case 2: Unexpected status
+	unavailable := kratos.New(config.KratosConfig{WhoamiURL: server.URL + "/unavailable", SessionCookie: sessionCookie, Timeout: time.Second})
+	assert.NotNil(t, unavailable.Check(context.Background()))
+}
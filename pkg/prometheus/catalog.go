@@ -0,0 +1,91 @@
+package prometheus
+
+import (
+	"context"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// Catalog entities and actions counted by CatalogChanges
+const (
+	EntityApp    = "app"
+	EntityReview = "review"
+
+	ActionCreated = "created"
+	ActionUpdated = "updated"
+	ActionDeleted = "deleted"
+)
+
+// CatalogStatsSource provides the statistics behind the catalog gauges
+type CatalogStatsSource interface {
+	GetCatalogStats(ctx context.Context) (models.CatalogStats, error)
+}
+
+// CountCatalogChange counts n apps or reviews changed through the API
+func (m *PrometheusMetrics) CountCatalogChange(entity, action string, n int) {
+	m.CatalogChanges.WithLabelValues(entity, action).Add(float64(n))
+}
+
+// RefreshCatalog sets the catalog gauges from source
+func (m *PrometheusMetrics) RefreshCatalog(ctx context.Context, source CatalogStatsSource) error {
+	stats, err := source.GetCatalogStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.CatalogApps.Set(float64(stats.Apps))
+	m.CatalogReviews.Set(float64(stats.Reviews))
+
+	m.catalogMu.Lock()
+	defer m.catalogMu.Unlock()
+	m.catalogSentiments = setGauges(m.CatalogReviewsBySentiment, stats.ReviewsBySentiment, m.catalogSentiments)
+	m.catalogCategories = setGauges(m.CatalogAverageRating, stats.AverageRatingByCategory, m.catalogCategories)
+	return nil
+}
+
+// setGauges sets a gauge of vec per label value first, then deletes the
+// gauges of previous label values that are gone, so scrapes during a refresh
+// never miss a series. It returns the label values now set.
+func setGauges[V int64 | float64](vec *prometheus.GaugeVec, values map[string]V, previous []string) []string {
+	labels := make([]string, 0, len(values))
+	for label, value := range values {
+		vec.WithLabelValues(label).Set(float64(value))
+		labels = append(labels, label)
+	}
+	for _, label := range previous {
+		if _, ok := values[label]; !ok {
+			vec.DeleteLabelValues(label)
+		}
+	}
+	return labels
+}
+
+// RunCatalogRefresher refreshes the catalog gauges every interval until ctx
+// is done, so scrapes never hit the database themselves. A non-positive
+// interval refreshes once.
+func (m *PrometheusMetrics) RunCatalogRefresher(ctx context.Context, source CatalogStatsSource, interval time.Duration, logger *zap.Logger) {
+	if interval <= 0 {
+		if err := m.RefreshCatalog(ctx, source); err != nil {
+			logger.Error("error while refreshing catalog metrics", zap.Error(err))
+		}
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.RefreshCatalog(ctx, source); err != nil {
+			logger.Error("error while refreshing catalog metrics", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
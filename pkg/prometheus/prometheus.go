@@ -1,28 +1,129 @@
 package prometheus
 
 import (
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
 const Namespace = "golang_api"
 
+// UnmatchedRoute labels requests that did not match any route, so unknown
+// paths cannot blow up label cardinality
+const UnmatchedRoute = "unmatched"
+
 type PrometheusMetrics struct {
-	RequestsMetrics *prometheus.CounterVec
+	RequestsMetrics  *prometheus.CounterVec
+	RequestDuration  *prometheus.HistogramVec
+	RequestsInFlight prometheus.Gauge
+	ResponseSize     *prometheus.SummaryVec
+	QueryDuration    *prometheus.HistogramVec
+
+	// Catalog metrics, see catalog.go
+	CatalogApps               prometheus.Gauge
+	CatalogReviews            prometheus.Gauge
+	CatalogReviewsBySentiment *prometheus.GaugeVec
+	CatalogAverageRating      *prometheus.GaugeVec
+	CatalogChanges            *prometheus.CounterVec
+
+	// Label values set by the last RefreshCatalog, guarded by catalogMu
+	catalogMu         sync.Mutex
+	catalogSentiments []string
+	catalogCategories []string
 }
 
 var metrics *PrometheusMetrics = nil
 
-func InitPrometheusMetrics() *PrometheusMetrics {
+// InitPrometheusMetrics registers the API metrics once. Histogram buckets
+// default to the prometheus default buckets when not configured.
+func InitPrometheusMetrics(cfg config.MetricsConfig) *PrometheusMetrics {
 	if metrics == nil {
+		durationBuckets := cfg.DurationBuckets
+		if len(durationBuckets) == 0 {
+			durationBuckets = prometheus.DefBuckets
+		}
+		queryBuckets := cfg.QueryDurationBuckets
+		if len(queryBuckets) == 0 {
+			queryBuckets = prometheus.DefBuckets
+		}
+
 		metrics = &PrometheusMetrics{
 			RequestsMetrics: promauto.NewCounterVec(prometheus.CounterOpts{
 				Namespace: Namespace,
 				Name:      "requests_total",
 				Help:      "Total API requests",
 			}, []string{"code"}),
+			RequestDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+				Namespace: Namespace,
+				Name:      "request_duration_seconds",
+				Help:      "API request latency by method and route template",
+				Buckets:   durationBuckets,
+			}, []string{"method", "route"}),
+			RequestsInFlight: promauto.NewGauge(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "requests_in_flight",
+				Help:      "API requests currently being served",
+			}),
+			ResponseSize: promauto.NewSummaryVec(prometheus.SummaryOpts{
+				Namespace:  Namespace,
+				Name:       "response_size_bytes",
+				Help:       "API response body size by method and route template",
+				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+			}, []string{"method", "route"}),
+			QueryDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+				Namespace: Namespace,
+				Name:      "db_query_duration_seconds",
+				Help:      "Database query latency by model operation",
+				Buckets:   queryBuckets,
+			}, []string{"operation"}),
+			CatalogApps: promauto.NewGauge(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "catalog_apps",
+				Help:      "Number of apps in the catalog",
+			}),
+			CatalogReviews: promauto.NewGauge(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "catalog_reviews",
+				Help:      "Number of reviews in the catalog",
+			}),
+			CatalogReviewsBySentiment: promauto.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "catalog_reviews_by_sentiment",
+				Help:      "Number of reviews per sentiment",
+			}, []string{"sentiment"}),
+			CatalogAverageRating: promauto.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "catalog_average_rating",
+				Help:      "Average app rating per category",
+			}, []string{"category"}),
+			CatalogChanges: promauto.NewCounterVec(prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "catalog_changes_total",
+				Help:      "Apps and reviews created, updated and deleted through the API",
+			}, []string{"entity", "action"}),
 		}
 	}
 
 	return metrics
 }
+
+// ObserveQuery records the duration of a database query issued by operation
+func (m *PrometheusMetrics) ObserveQuery(operation string, duration time.Duration) {
+	m.QueryDuration.WithLabelValues(operation).Observe(duration.Seconds())
+}
+
+// RegisterDBStats exports the connection pool statistics of db, such as open,
+// in use and idle connections and the wait count and duration.
+func RegisterDBStats(db *sql.DB, dbName string) error {
+	err := prometheus.Register(collectors.NewDBStatsCollector(db, dbName))
+	if are := (prometheus.AlreadyRegisteredError{}); errors.As(err, &are) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// Limit is a token bucket holding up to Burst tokens, which refills with
+// Burst tokens every Period
+type Limit struct {
+	Burst  int
+	Period time.Duration
+}
+
+// rate is how many tokens are added per second
+func (limit Limit) rate() float64 {
+	return float64(limit.Burst) / limit.Period.Seconds()
+}
+
+// Result is the outcome of taking a token
+type Result struct {
+	Allowed bool
+	Limit   int
+	// Remaining is how many whole tokens are left in the bucket
+	Remaining int
+	// RetryAfter is how long until the next token, zero when Allowed
+	RetryAfter time.Duration
+	// ResetAfter is how long until the bucket is full again
+	ResetAfter time.Duration
+}
+
+// Bucket is the state of a token bucket. It is exported so stores keeping
+// buckets outside the process can share the refill arithmetic.
+type Bucket struct {
+	Tokens  float64   `json:"tokens"`
+	Updated time.Time `json:"updated"`
+}
+
+// Take refills the bucket for the time passed since it was last updated and
+// takes a token when one is left
+func (bucket *Bucket) Take(limit Limit, now time.Time) Result {
+	burst := float64(limit.Burst)
+	rate := limit.rate()
+
+	switch {
+	case bucket.Updated.IsZero():
+		bucket.Tokens = burst
+		bucket.Updated = now
+	case now.After(bucket.Updated):
+		// Instances sharing a store may disagree slightly on the time, a bucket
+		// is never refilled backwards
+		bucket.Tokens = math.Min(burst, bucket.Tokens+now.Sub(bucket.Updated).Seconds()*rate)
+		bucket.Updated = now
+	}
+
+	result := Result{Limit: limit.Burst}
+	if bucket.Tokens >= 1 {
+		bucket.Tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - bucket.Tokens) / rate)
+	}
+	result.Remaining = int(bucket.Tokens)
+	result.ResetAfter = seconds((burst - bucket.Tokens) / rate)
+	return result
+}
+
+// Full reports whether the bucket has refilled completely by now, so dropping
+// it changes nothing
+func (bucket *Bucket) Full(limit Limit, now time.Time) bool {
+	return now.Sub(bucket.Updated) >= limit.Period
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
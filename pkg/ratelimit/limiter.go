@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+)
+
+// Limiter applies separate limits to reads and writes of each client
+type Limiter struct {
+	store Store
+	read  Limit
+	write Limit
+}
+
+// New returns a limiter keeping buckets in store, or nil when neither reads
+// nor writes are limited by cfg
+func New(cfg config.RateLimitConfig, store Store) *Limiter {
+	if cfg.Read <= 0 && cfg.Write <= 0 || cfg.Period <= 0 {
+		return nil
+	}
+	return &Limiter{
+		store: store,
+		read:  Limit{Burst: cfg.Read, Period: cfg.Period},
+		write: Limit{Burst: cfg.Write, Period: cfg.Period},
+	}
+}
+
+// Take takes a token from the read or write bucket of client. The second
+// return value is false when that kind of request is not limited.
+func (limiter *Limiter) Take(ctx context.Context, client string, write bool) (Result, bool, error) {
+	limit, key := limiter.read, "read:"+client
+	if write {
+		limit, key = limiter.write, "write:"+client
+	}
+	if limit.Burst <= 0 {
+		return Result{}, false, nil
+	}
+
+	result, err := limiter.store.Take(ctx, key, limit, time.Now())
+	return result, true, err
+}
+
+// Limit returns the read or write limit
+func (limiter *Limiter) Limit(write bool) Limit {
+	if write {
+		return limiter.write
+	}
+	return limiter.read
+}
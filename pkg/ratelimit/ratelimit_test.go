@@ -0,0 +1,83 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBucket tests refilling and taking tokens
+func TestBucket(t *testing.T) {
+	limit := ratelimit.Limit{Burst: 10, Period: 10 * time.Second}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	bucket := ratelimit.Bucket{}
+
+	// Test case 1: A new bucket starts out full
+	t.Run("burst", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			result := bucket.Take(limit, start)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 9-i, result.Remaining)
+		}
+
+		result := bucket.Take(limit, start)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, time.Second, result.RetryAfter)
+		assert.Equal(t, 10*time.Second, result.ResetAfter)
+	})
+
+	// Test case 2: Tokens are added at Burst per Period
+	t.Run("refill", func(t *testing.T) {
+		result := bucket.Take(limit, start.Add(2500*time.Millisecond))
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 1, result.Remaining)
+
+		assert.True(t, bucket.Take(limit, start.Add(2500*time.Millisecond)).Allowed)
+		result = bucket.Take(limit, start.Add(2500*time.Millisecond))
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+	})
+
+	// Test case 3: Time going backwards doesn't refill the bucket
+	t.Run("clock skew", func(t *testing.T) {
+		assert.False(t, bucket.Take(limit, start).Allowed)
+	})
+
+	// Test case 4: Refilling stops at Burst
+	t.Run("cap at burst", func(t *testing.T) {
+		result := bucket.Take(limit, start.Add(time.Hour))
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 9, result.Remaining)
+		assert.True(t, bucket.Full(limit, start.Add(time.Hour+limit.Period)))
+	})
+}
+
+// TestLimiter tests limiting reads and writes of clients separately
+func TestLimiter(t *testing.T) {
+	limiter := ratelimit.New(config.RateLimitConfig{Read: 1, Period: time.Minute}, ratelimit.NewMemoryStore())
+	ctx := context.Background()
+
+	result, limited, err := limiter.Take(ctx, "client-1", false)
+	assert.Nil(t, err)
+	assert.True(t, limited)
+	assert.True(t, result.Allowed)
+
+	result, _, err = limiter.Take(ctx, "client-1", false)
+	assert.Nil(t, err)
+	assert.False(t, result.Allowed)
+
+	result, _, err = limiter.Take(ctx, "client-2", false)
+	assert.Nil(t, err)
+	assert.True(t, result.Allowed)
+
+	// Writes are not limited without a write limit
+	_, limited, err = limiter.Take(ctx, "client-1", true)
+	assert.Nil(t, err)
+	assert.False(t, limited)
+
+	assert.Nil(t, ratelimit.New(config.RateLimitConfig{Period: time.Minute}, ratelimit.NewMemoryStore()))
+}
@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store keeps the token buckets of all clients. Take must refill and take
+// from the bucket of key atomically, so deployments running several instances
+// can share buckets through a store backed by e.g. Redis.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+}
+
+// sweepInterval is how often the memory store drops buckets that refilled
+const sweepInterval = time.Minute
+
+type memoryEntry struct {
+	bucket Bucket
+	limit  Limit
+}
+
+// MemoryStore keeps buckets in process memory, each instance limits clients
+// on its own
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryEntry
+	lastSweep time.Time
+}
+
+// NewMemoryStore returns an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*memoryEntry{}}
+}
+
+// Take takes a token from the bucket of key
+func (store *MemoryStore) Take(_ context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if now.Sub(store.lastSweep) >= sweepInterval {
+		store.sweep(now)
+	}
+
+	entry, ok := store.buckets[key]
+	if !ok {
+		entry = &memoryEntry{}
+		store.buckets[key] = entry
+	}
+	entry.limit = limit
+	return entry.bucket.Take(limit, now), nil
+}
+
+// sweep drops full buckets, a new bucket starts out full anyway
+func (store *MemoryStore) sweep(now time.Time) {
+	for key, entry := range store.buckets {
+		if entry.bucket.Full(entry.limit, now) {
+			delete(store.buckets, key)
+		}
+	}
+	store.lastSweep = now
+}
@@ -0,0 +1,39 @@
+package rbac
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+)
+
+// ErrUnknownToken is returned by identity sources that don't know a token,
+// so the next kind of credentials can be tried
+var ErrUnknownToken = errors.New("unknown token")
+
+// Identity is a caller and their roles
+type Identity struct {
+	Subject string
+	Roles   []string
+}
+
+// IdentitySource resolves the bearer token of a request to its caller
+type IdentitySource interface {
+	Identify(ctx context.Context, token string) (Identity, error)
+}
+
+// StaticTokens is an IdentitySource backed by a fixed token to role map
+type StaticTokens map[string]string
+
+// Identify implements IdentitySource. The subject is derived from the hash of
+// the token so the token itself never ends up in logs.
+func (tokens StaticTokens) Identify(ctx context.Context, token string) (Identity, error) {
+	for known, role := range tokens {
+		if subtle.ConstantTimeCompare([]byte(known), []byte(token)) == 1 {
+			sum := sha256.Sum256([]byte(token))
+			return Identity{Subject: "token:" + hex.EncodeToString(sum[:4]), Roles: []string{role}}, nil
+		}
+	}
+	return Identity{}, ErrUnknownToken
+}
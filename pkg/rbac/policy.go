@@ -0,0 +1,137 @@
+package rbac
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Roles
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+)
+
+// Rule allows roles to call the routes matching Methods and Paths
+type Rule struct {
+	// Methods are HTTP methods, * matches any method
+	Methods []string `yaml:"methods" json:"methods"`
+	// Paths are route templates as registered in routes.Setup, like
+	// /api/v1/apps/:appID; * matches within a path segment
+	Paths []string `yaml:"paths" json:"paths"`
+	Roles []string `yaml:"roles" json:"roles"`
+}
+
+// Policy lists which roles may call which routes. A request is allowed when
+// any rule matching its method and route lists one of the caller's roles,
+// requests matching no rule are denied.
+type Policy struct {
+	Rules []Rule `yaml:"rules" json:"rules"`
+}
+
+// ParsePolicy decodes a YAML policy and checks its patterns
+func ParsePolicy(data []byte) (*Policy, error) {
+	policy := &Policy{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(policy); err != nil {
+		return nil, fmt.Errorf("decoding rbac policy: %w", err)
+	}
+
+	for i, rule := range policy.Rules {
+		if len(rule.Methods) == 0 || len(rule.Paths) == 0 || len(rule.Roles) == 0 {
+			return nil, fmt.Errorf("rbac policy rule %d: methods, paths and roles are required", i+1)
+		}
+		for j, method := range rule.Methods {
+			policy.Rules[i].Methods[j] = strings.ToUpper(method)
+		}
+		for _, pattern := range rule.Paths {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("rbac policy rule %d: path %q: %w", i+1, pattern, err)
+			}
+		}
+	}
+	return policy, nil
+}
+
+// Allowed reports whether a caller with roles may call route with method
+func (policy *Policy) Allowed(method, route string, roles []string) bool {
+	route = trimSlash(route)
+	for _, rule := range policy.Rules {
+		if !slices.Contains(rule.Methods, method) && !slices.Contains(rule.Methods, "*") {
+			continue
+		}
+		if !slices.ContainsFunc(rule.Paths, func(pattern string) bool {
+			matched, _ := path.Match(trimSlash(pattern), route)
+			return matched
+		}) {
+			continue
+		}
+		if slices.ContainsFunc(roles, func(role string) bool { return slices.Contains(rule.Roles, role) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// trimSlash drops the trailing slash fiber keeps on group index routes
+func trimSlash(route string) string {
+	if len(route) > 1 {
+		return strings.TrimSuffix(route, "/")
+	}
+	return route
+}
+
+// PolicyFile is a policy loaded from a file, which can be reloaded while
+// requests are being authorized
+type PolicyFile struct {
+	path   string
+	policy atomic.Pointer[Policy]
+	// reloadMu serializes reloads, readers never block
+	reloadMu sync.Mutex
+}
+
+// LoadPolicyFile reads the policy at path
+func LoadPolicyFile(path string) (*PolicyFile, error) {
+	file := &PolicyFile{path: path}
+	if err := file.Reload(); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Policy returns the policy loaded last
+func (file *PolicyFile) Policy() *Policy {
+	return file.policy.Load()
+}
+
+// Path is the file the policy is read from
+func (file *PolicyFile) Path() string {
+	return file.path
+}
+
+// Reload reads the file again. The current policy is kept when the file
+// can't be read or is invalid.
+func (file *PolicyFile) Reload() error {
+	file.reloadMu.Lock()
+	defer file.reloadMu.Unlock()
+
+	data, err := os.ReadFile(file.path)
+	if err != nil {
+		return fmt.Errorf("reading rbac policy: %w", err)
+	}
+	policy, err := ParsePolicy(data)
+	if err != nil {
+		return err
+	}
+	file.policy.Store(policy)
+	return nil
+}
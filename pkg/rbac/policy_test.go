@@ -0,0 +1,83 @@
+package rbac_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDefaultPolicy tests the policy shipped with the service
+func TestDefaultPolicy(t *testing.T) {
+	file, err := rbac.LoadPolicyFile("../../rbac_policy.yaml")
+	assert.Nil(t, err)
+	policy := file.Policy()
+
+	cases := []struct {
+		method, route         string
+		viewer, editor, admin bool
+	}{
+		{"GET", "/api/v1/apps/", true, true, true},
+		{"GET", "/api/v1/apps/:appID", true, true, true},
+		{"GET", "/api/v1/reviews/:id", true, true, true},
+		{"POST", "/api/v1/apps/", false, true, true},
+		{"PUT", "/api/v1/reviews/:id", false, true, true},
+		{"DELETE", "/api/v1/apps/:appID", false, false, true},
+		{"DELETE", "/api/v1/reviews/:id", false, false, true},
+		{"POST", "/api/v1/apps/:appID/merge", false, false, true},
+		{"PATCH", "/api/v1/apps/:appID", false, false, false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.viewer, policy.Allowed(c.method, c.route, []string{rbac.RoleViewer}), "viewer %s %s", c.method, c.route)
+		assert.Equal(t, c.editor, policy.Allowed(c.method, c.route, []string{rbac.RoleEditor}), "editor %s %s", c.method, c.route)
+		assert.Equal(t, c.admin, policy.Allowed(c.method, c.route, []string{rbac.RoleAdmin}), "admin %s %s", c.method, c.route)
+		assert.False(t, policy.Allowed(c.method, c.route, nil), "no role %s %s", c.method, c.route)
+	}
+}
+
+// TestPolicyReload tests reloading a changed policy file
+func TestPolicyReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	write := func(policy string) {
+		assert.Nil(t, os.WriteFile(path, []byte(policy), 0o600))
+	}
+	write("rules:\n  - methods: [GET]\n    paths: [/api/v1/*]\n    roles: [viewer]\n")
+
+	file, err := rbac.LoadPolicyFile(path)
+	assert.Nil(t, err)
+	assert.True(t, file.Policy().Allowed("GET", "/api/v1/apps", []string{rbac.RoleViewer}))
+	assert.False(t, file.Policy().Allowed("DELETE", "/api/v1/apps", []string{rbac.RoleViewer}))
+
+	// Test case 1: Changes apply after a reload
+	t.Run("reload changed file", func(t *testing.T) {
+		write("rules:\n  - methods: ['*']\n    paths: [/api/v1/*]\n    roles: [viewer]\n")
+		assert.Nil(t, file.Reload())
+		assert.True(t, file.Policy().Allowed("DELETE", "/api/v1/apps", []string{rbac.RoleViewer}))
+	})
+
+	// Test case 2: An invalid file keeps the current policy
+	t.Run("reload invalid file", func(t *testing.T) {
+		write("rules:\n  - methods: [GET]\n    roles: [viewer]\n")
+		assert.NotNil(t, file.Reload())
+		assert.True(t, file.Policy().Allowed("DELETE", "/api/v1/apps", []string{rbac.RoleViewer}))
+
+		write("rules:\n  - method: [GET]\n")
+		assert.NotNil(t, file.Reload())
+	})
+}
+
+// TestStaticTokens tests resolving roles from the static token map
+func TestStaticTokens(t *testing.T) {
+	tokens := rbac.StaticTokens{"editor-token": rbac.RoleEditor}
+
+	identity, err := tokens.Identify(context.Background(), "editor-token")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{rbac.RoleEditor}, identity.Roles)
+	assert.NotContains(t, identity.Subject, "editor-token")
+
+	_, err = tokens.Identify(context.Background(), "other-token")
+	assert.ErrorIs(t, err, rbac.ErrUnknownToken)
+}
@@ -4,13 +4,13 @@ package structs
 type App struct {
 	App           string  `json:"app" validate:"required"`
 	Category      string  `json:"category" validate:"required"`
-	Rating        float64 `json:"rating" validate:"required"`
-	Reviews       int     `json:"reviews" validate:"required"`
+	Rating        float64 `json:"rating" validate:"gte=0,lte=5"`
+	Reviews       int     `json:"reviews" validate:"gte=0"`
 	Size          string  `json:"size" validate:"required"`
 	Installs      string  `json:"installs" validate:"required"`
-	Type          string  `json:"type" validate:"required"`
+	Type          string  `json:"type" validate:"required,oneof=Free Paid"`
 	Price         string  `json:"price" validate:"required"`
-	ContentRating string  `json:"content_rating" validate:"required"`
+	ContentRating string  `json:"content_rating" validate:"required,content_rating"`
 	Genres        string  `json:"genres" validate:"required"`
 	LastUpdated   string  `json:"last_updated" validate:"required"`
 	CurrentVer    string  `json:"current_ver" validate:"required"`
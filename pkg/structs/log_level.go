@@ -0,0 +1,10 @@
+package structs
+
+// LogLevel struct represents the request payload for changing a log level
+type LogLevel struct {
+	Level string `json:"level" validate:"required,oneof=debug info warn error"`
+	// Logger is the name of the logger to change, the root logger when empty
+	Logger string `json:"logger"`
+	// RevertAfter is a duration such as "10m" after which the level is restored
+	RevertAfter string `json:"revert_after"`
+}
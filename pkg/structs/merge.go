@@ -0,0 +1,6 @@
+package structs
+
+// MergeApps struct represents the request payload for merging duplicate apps
+type MergeApps struct {
+	DuplicateIDs []int `json:"duplicate_ids" validate:"required,min=1,dive,gt=0"`
+}
@@ -12,9 +12,9 @@ type Review struct {
 	ReviewID              int             `json:"id" db:"id"`
 	App                   string          `json:"app" db:"app" validate:"required"`
 	TranslatedReview      string          `json:"translated_review" db:"translated_review" validate:"required"`
-	Sentiment             string          `json:"sentiment" db:"sentiment" validate:"required"`
-	SentimentPolarity     NullableFloat64 `json:"sentiment_polarity" db:"sentiment_polarity"`
-	SentimentSubjectivity NullableFloat64 `json:"sentiment_subjectivity" db:"sentiment_subjectivity"`
+	Sentiment             string          `json:"sentiment" db:"sentiment" validate:"required,oneof=Positive Neutral Negative"`
+	SentimentPolarity     NullableFloat64 `json:"sentiment_polarity" db:"sentiment_polarity" validate:"omitempty,gte=-1,lte=1"`
+	SentimentSubjectivity NullableFloat64 `json:"sentiment_subjectivity" db:"sentiment_subjectivity" validate:"omitempty,gte=0,lte=1"`
 }
 
 // NullableFloat64 is a custom type that handles nullable float64 values
@@ -3,79 +3,161 @@ package routes
 import (
 	"fmt"
 
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/config"
 	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
 	controllers "git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/controllers/api/v1"
+	appLogger "git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/logger"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/middlewares"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/models"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
 	"github.com/doug-martin/goqu/v9"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 
 	// Adjust the import path if necessary
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/health"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/jwks"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/kratos"
 	pMetrics "git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/prometheus"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/ratelimit"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/rbac"
 )
 
 // Setup function to include App routes
-func Setup(app *fiber.App, goqu *goqu.Database, logger *zap.Logger, pMetrics *pMetrics.PrometheusMetrics) error { // Added pMetrics
+func Setup(app *fiber.App, cfg config.AppConfig, goqu *goqu.Database, logger *zap.Logger, pMetrics *pMetrics.PrometheusMetrics, checks *health.Registry, state *health.State) error { // Added pMetrics
+	RegisterValidations(utils.Validator)
+
+	apiKeys, err := models.InitAPIKeyModel(goqu)
+	if err != nil {
+		return err
+	}
+	credentials := middlewares.Credentials{
+		APIKeys:  &apiKeys,
+		JWT:      jwks.New(cfg.JWT),
+		Sessions: kratos.New(cfg.Kratos),
+	}
+	if len(cfg.RBAC.Tokens) > 0 {
+		credentials.Identities = rbac.StaticTokens(cfg.RBAC.Tokens)
+	}
+	// Clients can authenticate in other ways while these are down
+	if credentials.JWT != nil {
+		if err := checks.Register(health.Check{Name: "jwks", Checker: credentials.JWT}); err != nil {
+			return err
+		}
+	}
+	if credentials.Sessions != nil {
+		if err := checks.Register(health.Check{Name: "kratos", Checker: credentials.Sessions}); err != nil {
+			return err
+		}
+	}
+	policy, err := rbac.LoadPolicyFile(cfg.RBAC.PolicyFile)
+	if err != nil {
+		return err
+	}
+	// Buckets are kept per instance, a shared ratelimit.Store spreads them across instances
+	limiter := ratelimit.New(cfg.RateLimit, ratelimit.NewMemoryStore())
+	middleware := middlewares.NewMiddleware(cfg, credentials, policy, limiter, logger)
+
 	router := app.Group("/api")
 	v1 := router.Group("/v1")
 
 	// Setup other routes...
-	err := setupAppController(v1, goqu, logger, pMetrics) // Pass pMetrics
+	err = setupAppController(v1, goqu, logger, pMetrics, middleware) // Pass pMetrics
 	if err != nil {
 		return err
 	}
 	// Setup Review routes
-	err = setupReviewController(v1, goqu, logger, pMetrics)
+	err = setupReviewController(v1, goqu, logger, pMetrics, middleware)
 	if err != nil {
 		return err
 	}
 
-	err = healthCheckController(app, goqu, logger)
+	err = healthCheckController(app, cfg, goqu, logger, checks, state)
+	if err != nil {
+		return err
+	}
+
+	err = setupAdminController(app, cfg, logger, middleware, policy)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func setupAppController(v1 fiber.Router, goqu *goqu.Database, logger *zap.Logger, pMetrics *pMetrics.PrometheusMetrics) error { // Added pMetrics
-	appController, err := controllers.NewAppController(goqu, logger)
+func setupAppController(v1 fiber.Router, goqu *goqu.Database, logger *zap.Logger, pMetrics *pMetrics.PrometheusMetrics, middleware middlewares.Middleware) error { // Added pMetrics
+	appController, err := controllers.NewAppController(goqu, logger, pMetrics)
 	if err != nil {
 		return err
 	}
 
 	appRouter := v1.Group("/apps") // Define the /apps route group
 
+	read := middleware.Authorize(models.ScopeAppsRead)
+	write := middleware.Authorize(models.ScopeAppsWrite)
+
 	// Define the specific routes within the /apps group
-	appRouter.Get(fmt.Sprintf("/:%s", constants.ParamAppID), appController.GetApp) // GET /api/v1/apps/:appId
-	appRouter.Get("/", appController.GetApps)
-	appRouter.Post("/", appController.CreateApp) // GET /api/v1/apps/
-	appRouter.Delete(fmt.Sprintf("/:%s", constants.ParamAppID), appController.DeleteApp)
-	appRouter.Put(fmt.Sprintf("/:%s", constants.ParamAppID), appController.UpdateApp)
+	appRouter.Get("/duplicates", read, appController.GetDuplicates)                      // must be registered before /:appId
+	appRouter.Get(fmt.Sprintf("/:%s", constants.ParamAppID), read, appController.GetApp) // GET /api/v1/apps/:appId
+	appRouter.Get("/", read, appController.GetApps)
+	appRouter.Post("/", write, appController.CreateApp) // GET /api/v1/apps/
+	appRouter.Delete(fmt.Sprintf("/:%s", constants.ParamAppID), write, appController.DeleteApp)
+	appRouter.Put(fmt.Sprintf("/:%s", constants.ParamAppID), write, appController.UpdateApp)
+	appRouter.Post(fmt.Sprintf("/:%s/merge", constants.ParamAppID), write, appController.MergeApps)
 	return nil
 }
-func setupReviewController(v1 fiber.Router, goqu *goqu.Database, logger *zap.Logger, pMetrics *pMetrics.PrometheusMetrics) error {
-	reviewController, err := controllers.NewReviewController(goqu, logger)
+func setupReviewController(v1 fiber.Router, goqu *goqu.Database, logger *zap.Logger, pMetrics *pMetrics.PrometheusMetrics, middleware middlewares.Middleware) error {
+	reviewController, err := controllers.NewReviewController(goqu, logger, pMetrics)
 	if err != nil {
 		return err
 	}
 
 	reviewRouter := v1.Group("/reviews")
 
-	reviewRouter.Get(fmt.Sprintf("/:%s", constants.ParamReviewID), reviewController.GetReview) // GET /api/v1/reviews/:id
-	reviewRouter.Get("/", reviewController.GetReviews)
-	reviewRouter.Post("/", reviewController.CreateReviewData)
-	reviewRouter.Delete(fmt.Sprintf("/:%s", constants.ParamReviewID), reviewController.DeleteReview)
-	reviewRouter.Put(fmt.Sprintf("/:%s", constants.ParamReviewID), reviewController.UpdateReview)
+	// Reviews are app data, reading them only needs apps:read
+	read := middleware.Authorize(models.ScopeAppsRead)
+	write := middleware.Authorize(models.ScopeReviewsWrite)
+
+	reviewRouter.Get(fmt.Sprintf("/:%s", constants.ParamReviewID), read, reviewController.GetReview) // GET /api/v1/reviews/:id
+	reviewRouter.Get("/", read, reviewController.GetReviews)
+	reviewRouter.Post("/", write, reviewController.CreateReviewData)
+	reviewRouter.Delete(fmt.Sprintf("/:%s", constants.ParamReviewID), write, reviewController.DeleteReview)
+	reviewRouter.Put(fmt.Sprintf("/:%s", constants.ParamReviewID), write, reviewController.UpdateReview)
 
 	return nil
 }
-func healthCheckController(app *fiber.App, goqu *goqu.Database, logger *zap.Logger) error {
-	healthController, err := controllers.NewHealthController(goqu, logger)
+func healthCheckController(app *fiber.App, cfg config.AppConfig, goqu *goqu.Database, logger *zap.Logger, checks *health.Registry, state *health.State) error {
+	healthController, err := controllers.NewHealthController(checks, state, logger)
 	if err != nil {
 		return err
 	}
 
+	// Probes for the orchestrator
+	app.Get("/livez", healthController.Live)
+	app.Get("/readyz", healthController.Ready)
+	app.Get("/startupz", healthController.Started)
+
 	healthz := app.Group("/healthz")
 	healthz.Get("/", healthController.Overall)
+	healthz.Get("/self", healthController.Self)
 	healthz.Get("/db", healthController.Db)
 	return nil
 }
+
+func setupAdminController(app *fiber.App, cfg config.AppConfig, logger *zap.Logger, middleware middlewares.Middleware, policy *rbac.PolicyFile) error {
+	logLevelController, err := controllers.NewLogLevelController(appLogger.RuntimeLevels(), cfg.Logging.LevelRevertAfter, logger)
+	if err != nil {
+		return err
+	}
+	rbacController, err := controllers.NewRBACController(policy, logger)
+	if err != nil {
+		return err
+	}
+
+	admin := app.Group("/admin", middleware.AdminAuth)
+	admin.Get("/log-level", logLevelController.GetLogLevel)
+	admin.Put("/log-level", logLevelController.SetLogLevel)
+	admin.Delete("/log-level", logLevelController.RevertLogLevel)
+	admin.Get("/rbac/policy", rbacController.GetPolicy)
+	admin.Post("/rbac/reload", rbacController.ReloadPolicy)
+	return nil
+}
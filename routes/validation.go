@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"reflect"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/models"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/pkg/structs"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/utils"
+	"github.com/go-playground/validator/v10"
+)
+
+// RegisterValidations registers the rules tied to the model and request
+// types into v. The models and structs packages declare the same App and
+// NullableFloat64 shapes, so each rule reads their fields by name.
+func RegisterValidations(v *validator.Validate) {
+	// Validate nullable floats by their value, and skip them when null
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		if field.FieldByName("Valid").Bool() {
+			return field.FieldByName("Float64").Float()
+		}
+		return nil
+	}, models.NullableFloat64{}, structs.NullableFloat64{})
+
+	v.RegisterStructValidation(func(sl validator.StructLevel) {
+		app := sl.Current()
+		utils.ValidateAppPrice(sl, app.FieldByName("Type").String(), app.FieldByName("Price").String())
+	}, models.App{}, structs.App{})
+}
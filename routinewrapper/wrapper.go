@@ -1,16 +1,39 @@
 package routinewrapper
 
 import (
+	"context"
+	"fmt"
+	"runtime/debug"
 	"sync"
 )
 
+// ErrorHandler reports the error returned by, or the panic of, a named routine
+type ErrorHandler func(ctx context.Context, name string, err error)
+
+// PanicError is reported when a routine panics
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
 var handle func()
+var handleError ErrorHandler = func(context.Context, string, error) {}
 var _once sync.Once
 
-func Init(fn func()) {
+// routines counts the routines started with Go that are still running
+var routines sync.WaitGroup
+
+// Init sets the function deferred by RoutineGenerator and the handler
+// receiving the errors and panics of routines started with Go
+func Init(fn func(), onError ErrorHandler) {
 	_once.Do(func() {
 		// this sets the global handle function
 		handle = fn
+		handleError = onError
 	})
 }
 
@@ -18,3 +41,39 @@ func RoutineGenerator(fn func()) {
 	defer handle()
 	fn()
 }
+
+// Go runs fn in a new goroutine. A returned error or a panic is passed to
+// the error handler with name, so no goroutine can take the process down or
+// fail silently. fn should return once ctx is done.
+func Go(ctx context.Context, name string, fn func(ctx context.Context) error) {
+	routines.Add(1)
+	go func() {
+		defer routines.Done()
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				handleError(ctx, name, &PanicError{Value: recovered, Stack: debug.Stack()})
+			}
+		}()
+
+		if err := fn(ctx); err != nil {
+			handleError(ctx, name, err)
+		}
+	}()
+}
+
+// Wait blocks until every routine started with Go returned, or until ctx is
+// done, in which case the error of ctx is returned
+func Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		routines.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,71 @@
+package routinewrapper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGo tests error reporting of background routines
+func TestGo(t *testing.T) {
+	type report struct {
+		name string
+		err  error
+	}
+	reports := make(chan report, 1)
+	handleError = func(ctx context.Context, name string, err error) {
+		reports <- report{name, err}
+	}
+
+	t.Run("returned error is reported", func(t *testing.T) {
+		Go(context.Background(), "failing routine", func(ctx context.Context) error {
+			return errors.New("routine failed")
+		})
+
+		r := <-reports
+		assert.Equal(t, "failing routine", r.name)
+		assert.EqualError(t, r.err, "routine failed")
+	})
+
+	t.Run("panic is recovered and reported", func(t *testing.T) {
+		Go(context.Background(), "panicking routine", func(ctx context.Context) error {
+			panic("routine panicked")
+		})
+
+		r := <-reports
+		assert.Equal(t, "panicking routine", r.name)
+		var panicErr *PanicError
+		assert.True(t, errors.As(r.err, &panicErr))
+		assert.Equal(t, "routine panicked", panicErr.Value)
+		assert.NotEmpty(t, panicErr.Stack)
+	})
+}
+
+// TestWait tests waiting for background routines to return
+func TestWait(t *testing.T) {
+	handleError = func(context.Context, string, error) {}
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	Go(ctx, "waiting routine", func(ctx context.Context) error {
+		<-release
+		<-ctx.Done()
+		return nil
+	})
+
+	t.Run("wait times out while routines run", func(t *testing.T) {
+		waitCtx, cancelWait := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancelWait()
+		assert.ErrorIs(t, Wait(waitCtx), context.DeadlineExceeded)
+	})
+
+	t.Run("wait returns once routines stopped", func(t *testing.T) {
+		close(release)
+		cancel()
+		waitCtx, cancelWait := context.WithTimeout(context.Background(), time.Second)
+		defer cancelWait()
+		assert.Nil(t, Wait(waitCtx))
+	})
+}
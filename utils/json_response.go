@@ -1,7 +1,10 @@
 package utils
 
 import (
+	"net/http"
+
 	"clevergo.tech/jsend"
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -11,6 +14,15 @@ type JSONResponse struct {
 	Data    interface{} `json:"data"`
 	Message string      `json:"message"`
 	Code    int         `json:"code"`
+
+	RequestID string `json:"request_id"`
+}
+
+// failureBody is a JSend fail or error body carrying the request ID, so a
+// client error can be matched to the server log lines of the request
+type failureBody struct {
+	jsend.Body
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // JSONSuccess is a generic success output writer
@@ -20,12 +32,31 @@ func JSONSuccess(c *fiber.Ctx, statusCode int, data interface{}) error {
 
 // JSONFail is a generic fail output writer
 // JSONFail can used for 4xx status code response
+// Clients accepting application/problem+json get an RFC 7807 response instead
 func JSONFail(c *fiber.Ctx, statusCode int, data interface{}) error {
-	return c.Status(statusCode).JSON(jsend.NewFail(data))
+	if WantsProblem(c) {
+		return JSONProblem(c, statusCode, data)
+	}
+	return c.Status(statusCode).JSON(failureBody{jsend.NewFail(data), RequestID(c)})
 }
 
 // JSONError is a generic error output writer
 // JSONError can used for 5xx status code response
+// Clients accepting application/problem+json get an RFC 7807 response instead
 func JSONError(c *fiber.Ctx, statusCode int, err string) error {
-	return c.Status(statusCode).JSON(jsend.NewError(err, statusCode, nil))
+	if WantsProblem(c) {
+		return JSONProblem(c, statusCode, err)
+	}
+	return c.Status(statusCode).JSON(failureBody{jsend.NewError(err, statusCode, nil), RequestID(c)})
+}
+
+// JSONValidationFail writes a fail response mapping each invalid field to its
+// error code and message. Errors other than validation errors are reported as
+// a 500 since they mean the payload could not be validated at all.
+func JSONValidationFail(c *fiber.Ctx, err error) error {
+	fields, ok := ValidationErrors(err)
+	if !ok {
+		return JSONError(c, http.StatusInternalServerError, constants.ErrorValidationFailed)
+	}
+	return JSONFail(c, http.StatusBadRequest, fields)
 }
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Logger returns the request scoped logger, which tags every entry with the
+// request ID, or fallback when the request ID middleware did not run.
+func Logger(c *fiber.Ctx, fallback *zap.Logger) *zap.Logger {
+	if logger, ok := c.Locals(constants.LocalLogger).(*zap.Logger); ok {
+		return logger
+	}
+	return fallback
+}
+
+// UserID returns the subject the authentication middlewares recorded for the
+// request, to attribute changes to their caller, or "" for anonymous requests.
+func UserID(c *fiber.Ctx) string {
+	userID, _ := c.Locals(constants.LocalUserID).(string)
+	return userID
+}
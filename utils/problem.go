@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"net/http"
+	"strings"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	"github.com/gofiber/fiber/v2"
+)
+
+// MIMEApplicationProblemJSON is the RFC 7807 media type
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// Problem is an RFC 7807 problem details object
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	// Errors is an extension member listing invalid fields of a validation problem
+	Errors map[string]FieldError `json:"errors,omitempty"`
+}
+
+// WantsProblem reports whether the client prefers problem+json over plain JSON
+func WantsProblem(c *fiber.Ctx) bool {
+	return c.Accepts(fiber.MIMEApplicationJSON, MIMEApplicationProblemJSON) == MIMEApplicationProblemJSON
+}
+
+// RequestID returns the ID of the current request, if any
+func RequestID(c *fiber.Ctx) string {
+	if id, ok := c.Locals(constants.LocalRequestID).(string); ok {
+		return id
+	}
+	if id := c.GetRespHeader(constants.HeaderRequestID); id != "" {
+		return id
+	}
+	return c.Get(constants.HeaderRequestID)
+}
+
+// NewProblem builds a problem for statusCode. data is either an error message
+// constant or the field errors of a failed validation.
+func NewProblem(c *fiber.Ctx, statusCode int, data interface{}) Problem {
+	problem := Problem{
+		Type:     constants.ProblemTypeDefault,
+		Title:    http.StatusText(statusCode),
+		Status:   statusCode,
+		Instance: RequestID(c),
+	}
+
+	switch detail := data.(type) {
+	case string:
+		problem.Type = problemType(detail)
+		problem.Detail = detail
+	case map[string]FieldError:
+		problem.Type = constants.ProblemTypeValidation
+		problem.Detail = constants.ErrorInvalidRequestBody
+		problem.Errors = detail
+	}
+	return problem
+}
+
+// JSONProblem is a problem+json output writer
+func JSONProblem(c *fiber.Ctx, statusCode int, data interface{}) error {
+	return c.Status(statusCode).JSON(NewProblem(c, statusCode, data), MIMEApplicationProblemJSON)
+}
+
+// problemType looks up the type URI of an error message. Messages built by
+// appending details to a constant resolve to the type of the longest constant
+// they start with.
+func problemType(detail string) string {
+	if problemType, ok := constants.ProblemTypes[detail]; ok {
+		return problemType
+	}
+	problemType, longest := constants.ProblemTypeDefault, 0
+	for message, messageType := range constants.ProblemTypes {
+		if len(message) > longest && strings.HasPrefix(detail, message) {
+			problemType, longest = messageType, len(message)
+		}
+	}
+	return problemType
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"testing"
+
+	"git.pride.improwised.dev/Onboarding-2025/Yash-Tilala/fiber-csv-app/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestProblemType tests looking up the problem type of error messages
+func TestProblemType(t *testing.T) {
+	tests := []struct {
+		name   string
+		detail string
+		want   string
+	}{
+		{
+			// Test case 1: Constants resolve to their own type
+			name:   "exact",
+			detail: constants.FailedToGetReview,
+			want:   constants.ProblemTypes[constants.FailedToGetReview],
+		},
+		{
+			// Test case 2: A constant that another constant starts with keeps its own type
+			name:   "exact with shorter prefix",
+			detail: constants.FailedToGetReviews,
+			want:   constants.ProblemTypes[constants.FailedToGetReviews],
+		},
+		{
+			// Test case 3: Messages with appended details resolve to the longest matching constant
+			name:   "longest prefix",
+			detail: constants.FailedToGetReviews + ": connection refused",
+			want:   constants.ProblemTypes[constants.FailedToGetReviews],
+		},
+		{
+			// Test case 4: Unknown messages have the default type
+			name:   "unknown",
+			detail: "Something else went wrong",
+			want:   constants.ProblemTypeDefault,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Map iteration order varies, so look up repeatedly
+			for i := 0; i < 20; i++ {
+				assert.Equal(t, tt.want, problemType(tt.detail))
+			}
+		})
+	}
+}
@@ -1,28 +1,135 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/samber/lo"
 )
 
-const VALIDATE_MESSAGE = "fields are invalid."
+// Domain values accepted for app and review payloads
+var (
+	AppTypes       = []string{"Free", "Paid"}
+	ContentRatings = []string{"Everyone", "Everyone 10+", "Teen", "Mature 17+", "Adults only 18+", "Unrated"}
+)
+
+// Machine readable validation error codes
+const (
+	ValidationCodeRequired   = "required"
+	ValidationCodeTooSmall   = "too_small"
+	ValidationCodeTooLarge   = "too_large"
+	ValidationCodeNotAllowed = "not_allowed"
+	ValidationCodePriceType  = "price_type_mismatch"
+	ValidationCodeInvalid    = "invalid"
+)
+
+// Custom validation tags
+const (
+	tagContentRating = "content_rating"
+	TagPriceType     = "price_type"
+)
+
+// FieldError describes why a single request field is invalid
+type FieldError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Validator is the validator shared by all request handlers; it is safe for
+// concurrent use and caches struct metadata between requests.
+var Validator = NewValidator()
 
 func ValidateEmail(email string) (bool, error) {
 	return regexp.MatchString("[a-zA-z]+@improwised.com", email)
 }
 
-func ValidatorErrorString(err error) string {
-	var msg string
-	if err != nil {
-		for _, err := range err.(validator.ValidationErrors) {
-			msg += strings.ToLower(err.Field()) + ","
+// NewValidator returns a validator with the domain rules that apply to plain
+// fields registered. Rules tied to a type, like the price of an App or the
+// value of a NullableFloat64, are registered by routes.RegisterValidations.
+func NewValidator() *validator.Validate {
+	validate := validator.New()
+
+	// Report fields by their JSON name so errors match the request payload
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	_ = validate.RegisterValidation(tagContentRating, func(fl validator.FieldLevel) bool {
+		return lo.Contains(ContentRatings, fl.Field().String())
+	})
+
+	return validate
+}
+
+// ValidateAppPrice reports a price_type error for the price field of the
+// struct validated by sl when price doesn't match appType
+func ValidateAppPrice(sl validator.StructLevel, appType, price string) {
+	if !priceMatchesType(appType, price) {
+		sl.ReportError(price, "price", "Price", TagPriceType, appType)
+	}
+}
+
+// priceMatchesType checks that Free apps cost nothing and Paid apps cost
+// something. Prices may be written as "0" or "$4.99".
+func priceMatchesType(appType, price string) bool {
+	// An unknown type is reported by its own oneof rule
+	if !lo.Contains(AppTypes, appType) {
+		return true
+	}
+	value, err := strconv.ParseFloat(strings.TrimPrefix(strings.TrimSpace(price), "$"), 64)
+	if err != nil || value < 0 {
+		return false
+	}
+	if appType == "Free" {
+		return value == 0
+	}
+	return value > 0
+}
+
+// ValidationErrors maps the JSON name of each invalid field to its error.
+// ok is false when err is not a validator.ValidationErrors, e.g. an
+// InvalidValidationError caused by validating a non-struct value.
+func ValidationErrors(err error) (fields map[string]FieldError, ok bool) {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil, false
+	}
+
+	fields = map[string]FieldError{}
+	for _, fieldErr := range validationErrs {
+		fields[fieldErr.Field()] = newFieldError(fieldErr)
+	}
+	return fields, true
+}
+
+func newFieldError(fieldErr validator.FieldError) FieldError {
+	switch fieldErr.Tag() {
+	case "required":
+		return FieldError{ValidationCodeRequired, "is required"}
+	case "gte", "min":
+		return FieldError{ValidationCodeTooSmall, fmt.Sprintf("must be greater than or equal to %s", fieldErr.Param())}
+	case "gt":
+		return FieldError{ValidationCodeTooSmall, fmt.Sprintf("must be greater than %s", fieldErr.Param())}
+	case "lte", "max":
+		return FieldError{ValidationCodeTooLarge, fmt.Sprintf("must be less than or equal to %s", fieldErr.Param())}
+	case "oneof":
+		return FieldError{ValidationCodeNotAllowed, fmt.Sprintf("must be one of: %s", strings.Join(strings.Fields(fieldErr.Param()), ", "))}
+	case tagContentRating:
+		return FieldError{ValidationCodeNotAllowed, fmt.Sprintf("must be one of: %s", strings.Join(ContentRatings, ", "))}
+	case TagPriceType:
+		if fieldErr.Param() == "Paid" {
+			return FieldError{ValidationCodePriceType, "must be greater than 0 for Paid apps"}
 		}
-		msg = strings.TrimSuffix(msg, ",")
-		msg = fmt.Sprintf("%s %s", msg, VALIDATE_MESSAGE)
-		return msg
+		return FieldError{ValidationCodePriceType, "must be 0 for Free apps"}
 	}
-	return ""
+	return FieldError{ValidationCodeInvalid, "is invalid"}
 }